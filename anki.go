@@ -0,0 +1,196 @@
+package jmdict
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// An AnkiField selects a column of a flashcard export.
+type AnkiField int
+
+const (
+	AnkiHeadword  AnkiField = iota // keb, or reb for kana-only pairs
+	AnkiReading                    // reb
+	AnkiFurigana                   // headword with Anki furigana markup, e.g. 食[た]べる
+	AnkiGlosses                    // glosses, numbered by sense
+	AnkiPositions                  // part-of-speech descriptions
+	AnkiTags                       // misc and field codes, for the Anki tags column
+)
+
+// AnkiOptions configures WriteAnki.
+type AnkiOptions struct {
+	// Columns to write, in order. Defaults to every AnkiField.
+	Fields []AnkiField
+
+	// Field separator, one of '\t', ',', ';', '|', ':' or ' ', which
+	// are the separators Anki recognizes. Defaults to '\t'.
+	Separator rune
+
+	// Languages of the glosses to write. Defaults to DefaultGlossLang.
+	Languages []string
+
+	// Entries for which Filter returns false are skipped. A nil Filter
+	// keeps every entry.
+	Filter func(*Entry) bool
+
+	// Write a card for every valid kanji/reading pair rather than only
+	// the first pair of each entry.
+	AllPairs bool
+}
+
+// ErrAnkiSeparator is returned by WriteAnki for a separator which Anki
+// does not recognize.
+var ErrAnkiSeparator = errors.New("jmdict: separator not supported by Anki")
+
+// Anki's names for the separators it recognizes.
+var ankiSeparators = map[rune]string{
+	'\t': "tab",
+	',':  "comma",
+	';':  "semicolon",
+	'|':  "pipe",
+	':':  "colon",
+	' ':  "space",
+}
+
+// WriteAnki writes entries of dict as delimited text which Anki can
+// import, one card per line. Only kanji/reading pairs permitted by
+// re_restr and re_nokanji are written, and each card only includes the
+// senses which apply to its pair. The file declares its fields as
+// HTML, so text is escaped.
+func WriteAnki(w io.Writer, dict JMDict, opts AnkiOptions) error {
+	if len(opts.Fields) == 0 {
+		opts.Fields = []AnkiField{AnkiHeadword, AnkiReading, AnkiFurigana,
+			AnkiGlosses, AnkiPositions, AnkiTags}
+	}
+	if opts.Separator == 0 {
+		opts.Separator = '\t'
+	}
+	if len(opts.Languages) == 0 {
+		opts.Languages = []string{DefaultGlossLang}
+	}
+
+	separator, ok := ankiSeparators[opts.Separator]
+	if !ok {
+		return ErrAnkiSeparator
+	}
+	if _, err := fmt.Fprintf(w, "#separator:%s\n#html:true\n", separator); err != nil {
+		return err
+	}
+	for i, f := range opts.Fields {
+		if f == AnkiTags {
+			if _, err := fmt.Fprintf(w, "#tags column:%d\n", i+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	out := csv.NewWriter(w)
+	out.Comma = opts.Separator
+	record := make([]string, len(opts.Fields))
+	for i := range dict.Entries {
+		e := &dict.Entries[i]
+		if opts.Filter != nil && !opts.Filter(e) {
+			continue
+		}
+		pairs := e.Pairs()
+		if !opts.AllPairs && len(pairs) > 1 {
+			pairs = pairs[:1]
+		}
+		for _, pair := range pairs {
+			for j, f := range opts.Fields {
+				record[j] = ankiColumn(e, pair, f, opts.Languages)
+			}
+			if err := out.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func ankiColumn(e *Entry, pair Pair, f AnkiField, langs []string) string {
+	switch f {
+	case AnkiHeadword:
+		return html.EscapeString(pair.Headword())
+	case AnkiReading:
+		return html.EscapeString(string(pair.Reading.Phrase))
+	case AnkiFurigana:
+		var b strings.Builder
+		for i, seg := range pair.Align() {
+			if seg.Reading == "" {
+				b.WriteString(html.EscapeString(seg.Text))
+				continue
+			}
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			fmt.Fprintf(&b, "%s[%s]", html.EscapeString(seg.Text), html.EscapeString(seg.Reading))
+		}
+		return b.String()
+	case AnkiGlosses:
+		var senses []string
+		for i := range e.Sense {
+			if !e.Sense[i].AppliesTo(pair) {
+				continue
+			}
+			if glosses := ankiGlosses(&e.Sense[i], langs); len(glosses) > 0 {
+				senses = append(senses, html.EscapeString(strings.Join(glosses, "; ")))
+			}
+		}
+		if len(senses) == 1 {
+			return senses[0]
+		}
+		for i := range senses {
+			senses[i] = fmt.Sprintf("%d. %s", i+1, senses[i])
+		}
+		return strings.Join(senses, "<br>")
+	case AnkiPositions:
+		var descs []string
+		for i := range e.Sense {
+			if !e.Sense[i].AppliesTo(pair) {
+				continue
+			}
+			for _, p := range e.Positions(i) {
+				if d := DescribePosition(p); d != "" && !containsString(descs, d) {
+					descs = append(descs, d)
+				}
+			}
+		}
+		return html.EscapeString(strings.Join(descs, "; "))
+	case AnkiTags:
+		var tags []string
+		for i := range e.Sense {
+			if !e.Sense[i].AppliesTo(pair) {
+				continue
+			}
+			for _, m := range e.Sense[i].Misc {
+				if !containsString(tags, string(m)) {
+					tags = append(tags, string(m))
+				}
+			}
+			for _, f := range e.Sense[i].Field {
+				if !containsString(tags, string(f)) {
+					tags = append(tags, string(f))
+				}
+			}
+		}
+		return strings.Join(tags, " ")
+	}
+	return ""
+}
+
+// Returns the glosses of s in the given languages.
+func ankiGlosses(s *Sense, langs []string) []string {
+	var glosses []string
+	for g, text := range s.Gloss {
+		if containsString(langs, s.GlossLanguage(g)) {
+			glosses = append(glosses, text)
+		}
+	}
+	return glosses
+}
@@ -0,0 +1,68 @@
+package jmdict
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteAnki(t *testing.T) {
+	dict := readSample(t)
+	dict.Entries = dict.Entries[:1] // 明白
+
+	var b bytes.Buffer
+	if err := WriteAnki(&b, dict, AnkiOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	want := "#separator:tab\n#html:true\n#tags column:6\n" +
+		"明白\tめいはく\t明白[めいはく]\tobvious; clear\t" +
+		"adjectival nouns or quasi-adjectives (keiyodoshi)\t\n"
+	if b.String() != want {
+		t.Errorf("got\n%q\nwant\n%q", b.String(), want)
+	}
+
+	b.Reset()
+	opts := AnkiOptions{
+		Fields:    []AnkiField{AnkiHeadword, AnkiGlosses},
+		Separator: ';',
+		Languages: []string{"fre"},
+	}
+	if err := WriteAnki(&b, dict, opts); err != nil {
+		t.Fatal(err)
+	}
+	want = "#separator:semicolon\n#html:true\n明白;évident\n"
+	if b.String() != want {
+		t.Errorf("got\n%q\nwant\n%q", b.String(), want)
+	}
+}
+
+func TestWriteAnkiSeparator(t *testing.T) {
+	dict := readSample(t)
+	for sep, name := range ankiSeparators {
+		var b bytes.Buffer
+		if err := WriteAnki(&b, dict, AnkiOptions{Separator: sep}); err != nil {
+			t.Errorf("separator %q: %v", sep, err)
+		} else if !strings.HasPrefix(b.String(), "#separator:"+name+"\n") {
+			t.Errorf("separator %q: header %q", sep, strings.SplitN(b.String(), "\n", 2)[0])
+		}
+	}
+	if err := WriteAnki(&bytes.Buffer{}, dict, AnkiOptions{Separator: '#'}); err != ErrAnkiSeparator {
+		t.Errorf("separator '#': got %v, want ErrAnkiSeparator", err)
+	}
+}
+
+func TestWriteAnkiEscape(t *testing.T) {
+	dict := readSample(t)
+	dict.Entries = dict.Entries[:1] // 明白
+	dict.Entries[0].Sense[0].Gloss[0] = "<b>obvious</b> & plain"
+
+	var b bytes.Buffer
+	opts := AnkiOptions{Fields: []AnkiField{AnkiHeadword, AnkiGlosses}}
+	if err := WriteAnki(&b, dict, opts); err != nil {
+		t.Fatal(err)
+	}
+	want := "#separator:tab\n#html:true\n明白\t&lt;b&gt;obvious&lt;/b&gt; &amp; plain; clear\n"
+	if b.String() != want {
+		t.Errorf("got\n%q\nwant\n%q", b.String(), want)
+	}
+}
@@ -0,0 +1,102 @@
+package jmdict
+
+import (
+	"strings"
+	"time"
+)
+
+// Layout of the upd_date element
+const auditDateLayout = "2006-01-02"
+
+// The kind of update recorded by an audit element.
+type UpdateKind int
+
+const (
+	UpdateOther   UpdateKind = iota // upd_detl not recognised
+	EntryCreated                    // Entry created
+	EntryAmended                    // Entry amended
+	EntryDeleted                    // Entry deleted
+	EntryRestored                   // Entry restored
+)
+
+// Matches audit elements of every kind in UpdatedBetween
+const AnyUpdate UpdateKind = -1
+
+var updateKinds = map[string]UpdateKind{
+	"entry created":  EntryCreated,
+	"entry amended":  EntryAmended,
+	"entry deleted":  EntryDeleted,
+	"entry restored": EntryRestored,
+}
+
+// Date parses upd_date. The result is in UTC.
+func (a Audit) Date() (time.Time, error) {
+	return time.Parse(auditDateLayout, strings.TrimSpace(a.UpdDate))
+}
+
+// Kind classifies upd_detl.
+func (a Audit) Kind() UpdateKind {
+	return updateKinds[strings.ToLower(strings.TrimSpace(a.UpdDetl))]
+}
+
+// Created returns the date on which the entry was created, if the entry
+// has an audit element recording it.
+func (e *Entry) Created() (time.Time, bool) {
+	for _, a := range e.Info.Audit {
+		if a.Kind() != EntryCreated {
+			continue
+		}
+		if t, err := a.Date(); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Updated returns the date of the most recent audit element of any kind.
+func (e *Entry) Updated() (time.Time, bool) {
+	var latest time.Time
+	found := false
+	for _, a := range e.Info.Audit {
+		if t, err := a.Date(); err == nil && (!found || t.After(latest)) {
+			latest, found = t, true
+		}
+	}
+	return latest, found
+}
+
+// ChangedSince returns the entries with an audit element of any kind
+// dated on or after t.
+func (d JMDict) ChangedSince(t time.Time) []Entry {
+	var entries []Entry
+	for i := range d.Entries {
+		if u, ok := d.Entries[i].Updated(); ok && !u.Before(t) {
+			entries = append(entries, d.Entries[i])
+		}
+	}
+	return entries
+}
+
+// UpdatedBetween returns the entries with an audit element of the given
+// kind dated in [from, to).
+func (d JMDict) UpdatedBetween(kind UpdateKind, from, to time.Time) []Entry {
+	var entries []Entry
+	for i := range d.Entries {
+		for _, a := range d.Entries[i].Info.Audit {
+			if kind != AnyUpdate && a.Kind() != kind {
+				continue
+			}
+			if t, err := a.Date(); err == nil && !t.Before(from) && t.Before(to) {
+				entries = append(entries, d.Entries[i])
+				break
+			}
+		}
+	}
+	return entries
+}
+
+// CreatedIn returns the entries created during the given year.
+func (d JMDict) CreatedIn(year int) []Entry {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return d.UpdatedBetween(EntryCreated, from, from.AddDate(1, 0, 0))
+}
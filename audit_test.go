@@ -0,0 +1,129 @@
+package jmdict
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Returns a dictionary whose entries carry the given audit elements,
+// each a date and a detail.
+func auditDict(audits ...[][2]string) JMDict {
+	var dict JMDict
+	for i, list := range audits {
+		e := Entry{Id: EntSeq(i + 1)}
+		for _, a := range list {
+			e.Info.Audit = append(e.Info.Audit, Audit{UpdDate: a[0], UpdDetl: a[1]})
+		}
+		dict.Entries = append(dict.Entries, e)
+	}
+	return dict
+}
+
+var auditSample = auditDict(
+	[][2]string{{"2013-05-11", "Entry created"}, {"2015-02-01", "Entry amended"}},
+	[][2]string{{" 2010-08-02\n", " entry Amended "}},
+	nil,
+	[][2]string{{"2012-13-40", "Entry created"}, {"2014-01-01", "Entry amended"}},
+	[][2]string{{"not a date", "Entry created"}},
+	[][2]string{{"2013-12-31", "Entry created"}, {"2016-01-01", "Entry deleted"}},
+)
+
+func TestAuditDate(t *testing.T) {
+	tests := []struct {
+		date string
+		want time.Time
+		ok   bool
+	}{
+		{"2013-05-11", date(2013, time.May, 11), true},
+		{" 2010-08-02\n", date(2010, time.August, 2), true},
+		{"2012-13-40", time.Time{}, false},
+		{"2013/05/11", time.Time{}, false},
+		{"", time.Time{}, false},
+	}
+	for _, test := range tests {
+		got, err := Audit{UpdDate: test.date}.Date()
+		if (err == nil) != test.ok || test.ok && !got.Equal(test.want) || test.ok && got.Location() != time.UTC {
+			t.Errorf("Date(%q) = %v, %v, want %v", test.date, got, err, test.want)
+		}
+	}
+}
+
+func TestAuditKind(t *testing.T) {
+	tests := map[string]UpdateKind{
+		"Entry created":    EntryCreated,
+		" entry Amended ":  EntryAmended,
+		"Entry deleted":    EntryDeleted,
+		"ENTRY RESTORED":   EntryRestored,
+		"Entry created by": UpdateOther,
+		"Links added":      UpdateOther,
+		"":                 UpdateOther,
+	}
+	for detl, want := range tests {
+		if got := (Audit{UpdDetl: detl}).Kind(); got != want {
+			t.Errorf("Kind(%q) = %v, want %v", detl, got, want)
+		}
+	}
+}
+
+func TestEntryCreatedUpdated(t *testing.T) {
+	tests := []struct {
+		created, updated time.Time
+	}{
+		{date(2013, time.May, 11), date(2015, time.February, 1)},
+		{time.Time{}, date(2010, time.August, 2)},
+		{time.Time{}, time.Time{}},
+		// A malformed date is ignored.
+		{time.Time{}, date(2014, time.January, 1)},
+		{time.Time{}, time.Time{}},
+		{date(2013, time.December, 31), date(2016, time.January, 1)},
+	}
+	for i, test := range tests {
+		e := &auditSample.Entries[i]
+		created, ok := e.Created()
+		if !created.Equal(test.created) || ok == test.created.IsZero() {
+			t.Errorf("%d: Created() = %v, %v, want %v", e.Id, created, ok, test.created)
+		}
+		updated, ok := e.Updated()
+		if !updated.Equal(test.updated) || ok == test.updated.IsZero() {
+			t.Errorf("%d: Updated() = %v, %v, want %v", e.Id, updated, ok, test.updated)
+		}
+	}
+}
+
+func TestDictAuditQueries(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []Entry
+		want []EntSeq
+	}{
+		{"ChangedSince", auditSample.ChangedSince(date(2014, time.January, 1)), []EntSeq{1, 4, 6}},
+		{"ChangedSince later", auditSample.ChangedSince(date(2016, time.January, 2)), []EntSeq{}},
+		{"UpdatedBetween amended",
+			auditSample.UpdatedBetween(EntryAmended, date(2010, time.January, 1), date(2014, time.January, 1)),
+			[]EntSeq{2}},
+		{"UpdatedBetween any",
+			auditSample.UpdatedBetween(AnyUpdate, date(2010, time.January, 1), date(2014, time.January, 1)),
+			[]EntSeq{1, 2, 6}},
+		{"UpdatedBetween deleted",
+			auditSample.UpdatedBetween(EntryDeleted, date(2016, time.January, 1), date(2016, time.January, 2)),
+			[]EntSeq{6}},
+		{"CreatedIn 2013", auditSample.CreatedIn(2013), []EntSeq{1, 6}},
+		{"CreatedIn 2012", auditSample.CreatedIn(2012), []EntSeq{}},
+	}
+	for _, test := range tests {
+		if got := entrySeqs(test.got); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: entries %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	// The sample records audits as JMdict does.
+	dict := readSample(t)
+	if got := entrySeqs(dict.CreatedIn(2013)); !reflect.DeepEqual(got, []EntSeq{1000220}) {
+		t.Errorf("sample created in 2013: %v, want [1000220]", got)
+	}
+}
@@ -0,0 +1,147 @@
+package jmdict
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// A Decompressor wraps a compressed stream in a reader of its
+// decompressed contents.
+type Decompressor func(r io.Reader) (io.Reader, error)
+
+type decompressor struct {
+	name  string
+	magic []byte
+	fn    Decompressor
+}
+
+var (
+	decompressorsMu sync.RWMutex
+	decompressors   = []decompressor{
+		{"gzip", []byte{0x1f, 0x8b}, func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		}},
+		{"bzip2", []byte("BZh"), func(r io.Reader) (io.Reader, error) {
+			return bzip2.NewReader(r), nil
+		}},
+		{"xz", []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, func(r io.Reader) (io.Reader, error) {
+			return xz.NewReader(r)
+		}},
+		{"zstd", []byte{0x28, 0xb5, 0x2f, 0xfd}, func(r io.Reader) (io.Reader, error) {
+			// A single goroutine decodes synchronously, so the
+			// decoder holds no resources once the input is read.
+			return zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		}},
+	}
+)
+
+// RegisterDecompressor sets the decompressor used by ReadAuto for input
+// starting with magic, replacing any decompressor for the same magic.
+// gzip, bzip2, xz and zstd are registered already.
+func RegisterDecompressor(name string, magic []byte, fn Decompressor) {
+	decompressorsMu.Lock()
+	defer decompressorsMu.Unlock()
+	for i := range decompressors {
+		if bytes.Equal(decompressors[i].magic, magic) {
+			decompressors[i].name, decompressors[i].fn = name, fn
+			return
+		}
+	}
+	decompressors = append(decompressors, decompressor{name, magic, fn})
+}
+
+// ErrUnknownFormat is returned by ReadAuto for input which is not in any
+// of the formats it reads.
+var ErrUnknownFormat = errors.New("jmdict: unknown dictionary format")
+
+// ReadAuto reads a dictionary from r, which may hold JMdict XML, a
+// binary snapshot written by WriteBinary or UTF-8 EDICT2, optionally
+// compressed with gzip, bzip2, xz, zstd or any format registered with
+// RegisterDecompressor. EDICT2 is recognized by its header line, whose
+// first field is "？？？" and which names the format, as in
+//
+//	　？？？ /EDICT2, EDRDG, Copyright .../
+//
+// Use ReadEDICT2 for EDICT2 without a header.
+func ReadAuto(r io.Reader) (JMDict, error) {
+	br := bufio.NewReader(r)
+	for {
+		d, err := sniffDecompressor(br)
+		if err != nil {
+			return JMDict{}, err
+		}
+		if d == nil {
+			break
+		}
+		if d.fn == nil {
+			return JMDict{}, fmt.Errorf("jmdict: no decompressor registered for %s input", d.name)
+		}
+		dr, err := d.fn(br)
+		if err != nil {
+			return JMDict{}, err
+		}
+		br = bufio.NewReader(dr)
+	}
+
+	if head, _ := br.Peek(len(binaryMagic)); string(head) == binaryMagic {
+		return ReadBinary(br)
+	}
+	head, _ := br.Peek(512)
+	text := strings.TrimLeft(string(head), "\ufeff \t\r\n")
+	switch {
+	case strings.HasPrefix(text, "<"):
+		return Read(br)
+	case isEDICTHeader(text):
+		return ReadEDICT2(br)
+	}
+	return JMDict{}, ErrUnknownFormat
+}
+
+// Open reads the dictionary file at path with ReadAuto.
+func Open(path string) (JMDict, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return JMDict{}, err
+	}
+	defer f.Close()
+	return ReadAuto(f)
+}
+
+// Returns the decompressor whose magic begins the input, or nil if the
+// input is not compressed.
+func sniffDecompressor(br *bufio.Reader) (*decompressor, error) {
+	decompressorsMu.RLock()
+	defer decompressorsMu.RUnlock()
+	for i := range decompressors {
+		d := &decompressors[i]
+		head, err := br.Peek(len(d.magic))
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if bytes.Equal(head, d.magic) {
+			c := *d
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+// Reports whether text starts with the header line of EDICT or EDICT2.
+func isEDICTHeader(text string) bool {
+	line := strings.TrimLeft(text, "　 ")
+	if i := strings.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	return strings.HasPrefix(line, "？？？") && strings.Contains(line, "/EDICT")
+}
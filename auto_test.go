@@ -0,0 +1,121 @@
+package jmdict
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+func TestReadAuto(t *testing.T) {
+	dict := readSample(t)
+	xml, err := os.ReadFile("testdata/sample.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bz2, err := os.ReadFile("testdata/sample.xml.bz2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	compress := func(w io.WriteCloser, b *bytes.Buffer) []byte {
+		if _, err := w.Write(xml); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return b.Bytes()
+	}
+
+	var gz, xzb, zst, bin bytes.Buffer
+	xw, err := xz.NewWriter(&xzb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw, err := zstd.NewWriter(&zst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteBinary(&bin, dict); err != nil {
+		t.Fatal(err)
+	}
+	// A compressed snapshot.
+	var binGz bytes.Buffer
+	gw := gzip.NewWriter(&binGz)
+	gw.Write(bin.Bytes())
+	gw.Close()
+
+	for _, test := range []struct {
+		name string
+		data []byte
+	}{
+		{"xml", xml},
+		{"gzip", compress(gzip.NewWriter(&gz), &gz)},
+		{"bzip2", bz2},
+		{"xz", compress(xw, &xzb)},
+		{"zstd", compress(zw, &zst)},
+		{"binary", bin.Bytes()},
+		{"gzip binary", binGz.Bytes()},
+	} {
+		got, err := ReadAuto(bytes.NewReader(test.data))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, dict) {
+			t.Errorf("%s: dictionary differs from Read", test.name)
+		}
+	}
+}
+
+func TestReadAutoEDICT(t *testing.T) {
+	var edict bytes.Buffer
+	if err := WriteEDICT2(&edict, readSample(t)); err != nil {
+		t.Fatal(err)
+	}
+	want, err := ReadEDICT2(bytes.NewReader(edict.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write(edict.Bytes())
+	gw.Close()
+
+	for _, test := range []struct {
+		name string
+		data []byte
+	}{
+		{"EDICT2", edict.Bytes()},
+		{"gzip EDICT2", gz.Bytes()},
+		{"EDRDG header", append([]byte("\ufeff　？？？ /EDICT2, EDRDG, Copyright/\n"),
+			edict.Bytes()[bytes.IndexByte(edict.Bytes(), '\n')+1:]...)},
+	} {
+		got, err := ReadAuto(bytes.NewReader(test.data))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: dictionary differs from ReadEDICT2", test.name)
+		}
+	}
+}
+
+func TestReadAutoUnknown(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"食べる [たべる] /(v1) to eat/EntL1358280/\n",
+		"？？？ not a header\n",
+		"{\"ent_seq\":1358280}\n",
+	} {
+		if _, err := ReadAuto(bytes.NewReader([]byte(data))); err != ErrUnknownFormat {
+			t.Errorf("ReadAuto(%q): %v, want ErrUnknownFormat", data, err)
+		}
+	}
+}
@@ -0,0 +1,517 @@
+package jmdict
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// The binary snapshot format is
+//
+//	magic "JMDB" | version uvarint | string table | dictionary | crc32
+//
+// Integers are uvarints, except priority ranks which are varints. The
+// string table holds every distinct string of the dictionary once,
+// including tags, phrases and XML names, and the dictionary refers to
+// strings by their index in the table. Slices are written as a count
+// followed by their elements, with a count of zero decoding as nil. The
+// trailing CRC-32 (IEEE, big endian) covers everything before it.
+//
+// Version 2 added the languages of glosses. Snapshots of other versions
+// are rejected with ErrBinaryVersion and must be written again.
+const (
+	binaryMagic   = "JMDB"
+	binaryVersion = 2
+)
+
+var (
+	ErrBinaryFormat   = errors.New("jmdict: not a binary dictionary snapshot")
+	ErrBinaryChecksum = errors.New("jmdict: binary dictionary checksum mismatch")
+	ErrBinaryVersion  = errors.New("jmdict: unsupported binary snapshot version")
+)
+
+// WriteBinary writes dict to w as a compact binary snapshot which
+// ReadBinary can load much faster than Read can parse the XML.
+func WriteBinary(w io.Writer, dict JMDict) error {
+	enc := binEncoder{index: make(map[string]uint64)}
+	enc.dict(&dict)
+
+	var tmp [binary.MaxVarintLen64]byte
+	crc := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+	bw.WriteString(binaryMagic)
+	bw.Write(tmp[:binary.PutUvarint(tmp[:], binaryVersion)])
+	enc.writeTo(bw)
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.BigEndian, crc.Sum32())
+}
+
+// ReadBinary loads a snapshot written by WriteBinary. The result is
+// identical to the dictionary which was written. Equal strings share
+// memory in the result.
+func ReadBinary(r io.Reader) (JMDict, error) {
+	var dict JMDict
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return dict, err
+	}
+	if len(data) < len(binaryMagic)+4 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return dict, ErrBinaryFormat
+	}
+	body := data[:len(data)-4]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(data[len(body):]) {
+		return dict, ErrBinaryChecksum
+	}
+
+	dec := binDecoder{data: body, off: len(binaryMagic)}
+	if v := dec.uvarint(); v != binaryVersion && dec.err == nil {
+		return dict, fmt.Errorf("%w %d", ErrBinaryVersion, v)
+	}
+	dec.table()
+	dec.dict(&dict)
+	if dec.err == nil && dec.off != len(body) {
+		dec.err = ErrBinaryFormat
+	}
+	return dict, dec.err
+}
+
+type binEncoder struct {
+	buf     bytes.Buffer
+	index   map[string]uint64
+	strings []string
+	tmp     [binary.MaxVarintLen64]byte
+
+	// Set when hashing content: XML names and the Code and Rank of
+	// priorities, which depend on how the dictionary was loaded, are
+	// left out, as are the text of re_nokanji and whether the language
+	// of a gloss was declared or defaulted. Content hashes thereby agree
+	// with CompareEntries.
+	content bool
+}
+
+// Writes the string table followed by the encoded data.
+func (e *binEncoder) writeTo(w *bufio.Writer) {
+	var tmp [binary.MaxVarintLen64]byte
+	w.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(e.strings)))])
+	for _, s := range e.strings {
+		w.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(s)))])
+		w.WriteString(s)
+	}
+	w.Write(e.buf.Bytes())
+}
+
+// Clears the encoder so that it may encode an unrelated value.
+func (e *binEncoder) reset() {
+	e.buf.Reset()
+	e.strings = e.strings[:0]
+	for k := range e.index {
+		delete(e.index, k)
+	}
+}
+
+func (e *binEncoder) uvarint(v uint64) {
+	e.buf.Write(e.tmp[:binary.PutUvarint(e.tmp[:], v)])
+}
+
+func (e *binEncoder) str(s string) {
+	i, ok := e.index[s]
+	if !ok {
+		i = uint64(len(e.strings))
+		e.index[s] = i
+		e.strings = append(e.strings, s)
+	}
+	e.uvarint(i)
+}
+
+func (e *binEncoder) name(n xml.Name) {
+	if e.content {
+		return
+	}
+	e.str(n.Space)
+	e.str(n.Local)
+}
+
+func (e *binEncoder) strs(list []string) {
+	e.uvarint(uint64(len(list)))
+	for _, s := range list {
+		e.str(s)
+	}
+}
+
+func (e *binEncoder) priority(name xml.Name, p Priority) {
+	e.name(name)
+	e.str(p.Raw)
+	if e.content {
+		return
+	}
+	e.str(string(p.Code))
+	e.buf.Write(e.tmp[:binary.PutVarint(e.tmp[:], int64(p.Rank))])
+}
+
+func (e *binEncoder) dict(d *JMDict) {
+	e.name(d.XMLName)
+	e.uvarint(uint64(len(d.Entries)))
+	for i := range d.Entries {
+		e.entry(&d.Entries[i])
+	}
+}
+
+func (e *binEncoder) entry(ent *Entry) {
+	e.name(ent.XMLName)
+	e.uvarint(uint64(ent.Id))
+
+	e.uvarint(uint64(len(ent.Kanji)))
+	for _, k := range ent.Kanji {
+		e.name(k.XMLName)
+		e.str(string(k.Phrase))
+		e.uvarint(uint64(len(k.Info)))
+		for _, o := range k.Info {
+			e.str(string(o))
+		}
+		e.uvarint(uint64(len(k.Priority)))
+		for _, p := range k.Priority {
+			e.priority(p.XMLName, p.Priority)
+		}
+	}
+
+	e.uvarint(uint64(len(ent.Reading)))
+	for _, r := range ent.Reading {
+		e.name(r.XMLName)
+		e.str(string(r.Phrase))
+		if r.ImproperReading == nil {
+			e.uvarint(0)
+		} else {
+			e.uvarint(1)
+			if !e.content {
+				e.str(*r.ImproperReading)
+			}
+		}
+		e.uvarint(uint64(len(r.Restrict)))
+		for _, s := range r.Restrict {
+			e.str(string(s))
+		}
+		e.uvarint(uint64(len(r.Orthography)))
+		for _, o := range r.Orthography {
+			e.str(string(o))
+		}
+		e.uvarint(uint64(len(r.Priority)))
+		for _, p := range r.Priority {
+			e.priority(p.XMLName, p.Priority)
+		}
+	}
+
+	info := &ent.Info
+	e.name(info.XMLName)
+	e.uvarint(uint64(len(info.Links)))
+	for _, l := range info.Links {
+		e.name(l.XMLName)
+		e.str(l.LinkTag)
+		e.str(l.LinkDesc)
+		e.str(l.LinkUri)
+	}
+	e.uvarint(uint64(len(info.Bibl)))
+	for _, b := range info.Bibl {
+		e.name(b.XMLName)
+		e.str(b.BibTag)
+		e.str(b.BibTxt)
+	}
+	e.strs(info.Etym)
+	e.uvarint(uint64(len(info.Audit)))
+	for _, a := range info.Audit {
+		e.name(a.XMLName)
+		e.str(a.UpdDate)
+		e.str(a.UpdDetl)
+	}
+
+	e.uvarint(uint64(len(ent.Sense)))
+	for i := range ent.Sense {
+		s := &ent.Sense[i]
+		e.name(s.XMLName)
+		e.strs(s.KanjiRestrict)
+		e.strs(s.ReadingRestrict)
+		e.uvarint(uint64(len(s.Position)))
+		for _, p := range s.Position {
+			e.str(string(p))
+		}
+		e.strs(s.Xref)
+		e.strs(s.Antonym)
+		e.uvarint(uint64(len(s.Field)))
+		for _, f := range s.Field {
+			e.str(string(f))
+		}
+		e.uvarint(uint64(len(s.Misc)))
+		for _, m := range s.Misc {
+			e.str(string(m))
+		}
+		e.strs(s.Info)
+		e.uvarint(uint64(len(s.LSource)))
+		for _, l := range s.LSource {
+			e.name(l.XMLName)
+			e.str(l.Lang)
+			e.str(l.Type)
+			e.str(l.Wasei)
+			e.str(l.Source)
+		}
+		e.uvarint(uint64(len(s.Dialect)))
+		for _, d := range s.Dialect {
+			e.str(string(d))
+		}
+		e.strs(s.Gloss)
+		e.strs(s.Example)
+		if !e.content {
+			e.strs(s.GlossLang)
+			continue
+		}
+		for j := range s.Gloss {
+			e.str(s.GlossLanguage(j))
+		}
+	}
+}
+
+type binDecoder struct {
+	data    []byte
+	off     int
+	err     error
+	strings []string
+}
+
+func (d *binDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data[d.off:])
+	if n <= 0 {
+		d.err = ErrBinaryFormat
+		return 0
+	}
+	d.off += n
+	return v
+}
+
+func (d *binDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.data[d.off:])
+	if n <= 0 {
+		d.err = ErrBinaryFormat
+		return 0
+	}
+	d.off += n
+	return v
+}
+
+// Reads a slice length. Every element occupies at least one byte, which
+// bounds the length by the remaining input.
+func (d *binDecoder) count() int {
+	n := d.uvarint()
+	if n > uint64(len(d.data)-d.off) {
+		d.err = ErrBinaryFormat
+		return 0
+	}
+	return int(n)
+}
+
+func (d *binDecoder) table() {
+	n := d.count()
+	d.strings = make([]string, n)
+	// Copy the table into a single string so that every entry shares
+	// one allocation.
+	start := d.off
+	offsets := make([][2]int, n)
+	for i := 0; i < n; i++ {
+		l := d.count()
+		offsets[i] = [2]int{d.off - start, d.off - start + l}
+		d.off += l
+	}
+	if d.err != nil {
+		return
+	}
+	blob := string(d.data[start:d.off])
+	for i, o := range offsets {
+		d.strings[i] = blob[o[0]:o[1]]
+	}
+}
+
+func (d *binDecoder) str() string {
+	i := d.uvarint()
+	if i >= uint64(len(d.strings)) {
+		if d.err == nil {
+			d.err = ErrBinaryFormat
+		}
+		return ""
+	}
+	return d.strings[i]
+}
+
+func (d *binDecoder) name() xml.Name {
+	return xml.Name{Space: d.str(), Local: d.str()}
+}
+
+func (d *binDecoder) strs() []string {
+	n := d.count()
+	if n == 0 {
+		return nil
+	}
+	list := make([]string, n)
+	for i := range list {
+		list[i] = d.str()
+	}
+	return list
+}
+
+func (d *binDecoder) priority() (xml.Name, Priority) {
+	name := d.name()
+	return name, Priority{
+		Raw:  d.str(),
+		Code: PriorityCode(d.str()),
+		Rank: int(d.varint()),
+	}
+}
+
+func (d *binDecoder) dict(dict *JMDict) {
+	dict.XMLName = d.name()
+	if n := d.count(); n > 0 {
+		dict.Entries = make([]Entry, n)
+		for i := range dict.Entries {
+			d.entry(&dict.Entries[i])
+			if d.err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (d *binDecoder) entry(ent *Entry) {
+	ent.XMLName = d.name()
+	ent.Id = EntSeq(d.uvarint())
+
+	if n := d.count(); n > 0 {
+		ent.Kanji = make([]KEle, n)
+		for i := range ent.Kanji {
+			k := &ent.Kanji[i]
+			k.XMLName = d.name()
+			k.Phrase = Keb(d.str())
+			if n := d.count(); n > 0 {
+				k.Info = make([]Orthography, n)
+				for j := range k.Info {
+					k.Info[j] = Orthography(d.str())
+				}
+			}
+			if n := d.count(); n > 0 {
+				k.Priority = make([]KePriority, n)
+				for j := range k.Priority {
+					k.Priority[j].XMLName, k.Priority[j].Priority = d.priority()
+				}
+			}
+		}
+	}
+
+	if n := d.count(); n > 0 {
+		ent.Reading = make([]REle, n)
+		for i := range ent.Reading {
+			r := &ent.Reading[i]
+			r.XMLName = d.name()
+			r.Phrase = Reb(d.str())
+			if d.uvarint() != 0 {
+				s := d.str()
+				r.ImproperReading = &s
+			}
+			if n := d.count(); n > 0 {
+				r.Restrict = make([]ReRestr, n)
+				for j := range r.Restrict {
+					r.Restrict[j] = ReRestr(d.str())
+				}
+			}
+			if n := d.count(); n > 0 {
+				r.Orthography = make([]Orthography, n)
+				for j := range r.Orthography {
+					r.Orthography[j] = Orthography(d.str())
+				}
+			}
+			if n := d.count(); n > 0 {
+				r.Priority = make([]RePriority, n)
+				for j := range r.Priority {
+					r.Priority[j].XMLName, r.Priority[j].Priority = d.priority()
+				}
+			}
+		}
+	}
+
+	info := &ent.Info
+	info.XMLName = d.name()
+	if n := d.count(); n > 0 {
+		info.Links = make([]Links, n)
+		for i := range info.Links {
+			info.Links[i] = Links{XMLName: d.name(),
+				LinkTag: d.str(), LinkDesc: d.str(), LinkUri: d.str()}
+		}
+	}
+	if n := d.count(); n > 0 {
+		info.Bibl = make([]Bibl, n)
+		for i := range info.Bibl {
+			info.Bibl[i] = Bibl{XMLName: d.name(), BibTag: d.str(), BibTxt: d.str()}
+		}
+	}
+	info.Etym = d.strs()
+	if n := d.count(); n > 0 {
+		info.Audit = make([]Audit, n)
+		for i := range info.Audit {
+			info.Audit[i] = Audit{XMLName: d.name(), UpdDate: d.str(), UpdDetl: d.str()}
+		}
+	}
+
+	if n := d.count(); n > 0 {
+		ent.Sense = make([]Sense, n)
+		for i := range ent.Sense {
+			s := &ent.Sense[i]
+			s.XMLName = d.name()
+			s.KanjiRestrict = d.strs()
+			s.ReadingRestrict = d.strs()
+			if n := d.count(); n > 0 {
+				s.Position = make([]Position, n)
+				for j := range s.Position {
+					s.Position[j] = Position(d.str())
+				}
+			}
+			s.Xref = d.strs()
+			s.Antonym = d.strs()
+			if n := d.count(); n > 0 {
+				s.Field = make([]Field, n)
+				for j := range s.Field {
+					s.Field[j] = Field(d.str())
+				}
+			}
+			if n := d.count(); n > 0 {
+				s.Misc = make([]Misc, n)
+				for j := range s.Misc {
+					s.Misc[j] = Misc(d.str())
+				}
+			}
+			s.Info = d.strs()
+			if n := d.count(); n > 0 {
+				s.LSource = make([]LSource, n)
+				for j := range s.LSource {
+					s.LSource[j] = LSource{XMLName: d.name(), Lang: d.str(),
+						Type: d.str(), Wasei: d.str(), Source: d.str()}
+				}
+			}
+			if n := d.count(); n > 0 {
+				s.Dialect = make([]Dialect, n)
+				for j := range s.Dialect {
+					s.Dialect[j] = Dialect(d.str())
+				}
+			}
+			s.Gloss = d.strs()
+			s.Example = d.strs()
+			s.GlossLang = d.strs()
+		}
+	}
+}
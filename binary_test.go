@@ -0,0 +1,100 @@
+package jmdict
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"reflect"
+	"testing"
+)
+
+func TestBinary(t *testing.T) {
+	dict := readSample(t)
+	var buf bytes.Buffer
+	if err := WriteBinary(&buf, dict); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadBinary(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, dict) {
+		t.Error("snapshot does not read back as the dictionary written")
+	}
+	if got := sampleEntry(t, read, 1358280).Sense[0].GlossLang; !reflect.DeepEqual(got, []string{"eng", "ger"}) {
+		t.Errorf("gloss languages = %q, want [eng ger]", got)
+	}
+
+	if _, err := ReadBinary(bytes.NewReader([]byte("<JMdict/>"))); err != ErrBinaryFormat {
+		t.Errorf("XML: %v, want ErrBinaryFormat", err)
+	}
+}
+
+func TestBinaryEmpty(t *testing.T) {
+	for _, dict := range []JMDict{{}, {Entries: []Entry{{Id: 1}}}} {
+		var buf bytes.Buffer
+		if err := WriteBinary(&buf, dict); err != nil {
+			t.Fatal(err)
+		}
+		read, err := ReadBinary(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(read, dict) {
+			t.Errorf("read %+v, want %+v", read, dict)
+		}
+	}
+}
+
+// Returns the sample as a snapshot with the given version, and a
+// checksum which matches it.
+func binaryWithVersion(t *testing.T, version byte) []byte {
+	var buf bytes.Buffer
+	if err := WriteBinary(&buf, readSample(t)); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	body := data[:len(data)-4]
+	body[len(binaryMagic)] = version
+	binary.BigEndian.PutUint32(data[len(body):], crc32.ChecksumIEEE(body))
+	return data
+}
+
+func TestBinaryVersion(t *testing.T) {
+	if _, err := ReadBinary(bytes.NewReader(binaryWithVersion(t, binaryVersion))); err != nil {
+		t.Errorf("version %d snapshot: %v", binaryVersion, err)
+	}
+	// Snapshots written before gloss languages were recorded, and by
+	// later versions of the package, are rejected.
+	for _, version := range []byte{0, 1, binaryVersion + 1} {
+		if _, err := ReadBinary(bytes.NewReader(binaryWithVersion(t, version))); !errors.Is(err, ErrBinaryVersion) {
+			t.Errorf("version %d snapshot: %v, want ErrBinaryVersion", version, err)
+		}
+	}
+}
+
+// Any damage to a snapshot is detected.
+func TestBinaryChecksum(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBinary(&buf, readSample(t)); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	for i := range data {
+		damaged := append([]byte(nil), data...)
+		damaged[i] ^= 0x40
+		want := ErrBinaryChecksum
+		if i < len(binaryMagic) {
+			want = ErrBinaryFormat
+		}
+		if _, err := ReadBinary(bytes.NewReader(damaged)); err != want {
+			t.Fatalf("byte %d damaged: %v, want %v", i, err, want)
+		}
+	}
+	for n := 0; n < len(data); n++ {
+		if _, err := ReadBinary(bytes.NewReader(data[:n])); err == nil {
+			t.Fatalf("snapshot truncated to %d bytes read without error", n)
+		}
+	}
+}
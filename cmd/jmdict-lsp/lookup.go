@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/0xfaded/jmdict"
+)
+
+const (
+	// The longest word tried when segmenting, in characters.
+	maxWord = 12
+
+	// How far before the cursor segmentation starts, in characters. Long
+	// runs of text are segmented from this point rather than from their
+	// start.
+	maxContext = 40
+
+	maxCompletions = 50
+)
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type documentID struct {
+	URI string `json:"uri"`
+}
+
+type positionParams struct {
+	TextDocument documentID `json:"textDocument"`
+	Position     position   `json:"position"`
+}
+
+type markupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type hover struct {
+	Contents markupContent `json:"contents"`
+	Range    lspRange      `json:"range"`
+}
+
+type textEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type completionItem struct {
+	Label         string         `json:"label"`
+	Kind          int            `json:"kind"`
+	Detail        string         `json:"detail,omitempty"`
+	Documentation *markupContent `json:"documentation,omitempty"`
+	TextEdit      *textEdit      `json:"textEdit,omitempty"`
+}
+
+type completionList struct {
+	IsIncomplete bool             `json:"isIncomplete"`
+	Items        []completionItem `json:"items"`
+}
+
+// Returns the line of text at pos and the index of pos in it, in runes.
+func lineAt(text string, pos position) ([]rune, int) {
+	lines := strings.Split(text, "\n")
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return nil, 0
+	}
+	line := []rune(strings.TrimSuffix(lines[pos.Line], "\r"))
+	units := 0
+	for i, c := range line {
+		if units >= pos.Character {
+			return line, i
+		}
+		units += utf16Len(c)
+	}
+	return line, len(line)
+}
+
+func utf16Len(c rune) int {
+	if c > 0xFFFF {
+		return 2
+	}
+	return 1
+}
+
+// Returns the position of the i'th rune of a line.
+func runePosition(line []rune, i, lineNo int) position {
+	units := 0
+	for _, c := range line[:i] {
+		units += utf16Len(c)
+	}
+	return position{lineNo, units}
+}
+
+// Reports whether c may be part of a Japanese word.
+func isJapanese(c rune) bool {
+	return unicode.In(c, unicode.Han, unicode.Hiragana, unicode.Katakana) ||
+		c == 'ー' || c == '々' || c == '〆' || c == 'ヶ'
+}
+
+// Returns the entries for the word under the cursor, or nil if there
+// are none. The run of Japanese text around the cursor is split into
+// words by taking the longest match from the left, so that in
+// 食べさせなかった the whole word is found from any of its characters.
+func (s *server) hover(line []rune, cursor, lineNo int) *hover {
+	if cursor >= len(line) || !isJapanese(line[cursor]) {
+		return nil
+	}
+	lo, hi := cursor, cursor+1
+	for lo > 0 && isJapanese(line[lo-1]) && cursor-lo < maxContext {
+		lo--
+	}
+	for hi < len(line) && isJapanese(line[hi]) && hi-cursor < maxWord {
+		hi++
+	}
+
+	for i := lo; i < hi; {
+		n, matches := s.longest(line[i:hi])
+		if cursor < i+n {
+			if len(matches) == 0 {
+				return nil
+			}
+			return &hover{
+				Contents: markupContent{"markdown", s.describe(matches)},
+				Range:    lspRange{runePosition(line, i, lineNo), runePosition(line, i+n, lineNo)},
+			}
+		}
+		i += n
+	}
+	return nil
+}
+
+// Returns the length of the longest word at the start of text, and its
+// matches. A character which starts no word is a word of its own.
+func (s *server) longest(text []rune) (int, []jmdict.Match) {
+	n := len(text)
+	if n > maxWord {
+		n = maxWord
+	}
+	for ; n > 0; n-- {
+		if matches := s.index.Inflected(string(text[:n])); len(matches) > 0 {
+			return n, matches
+		}
+	}
+	return 1, nil
+}
+
+// Writes matches as markdown, for instance
+//
+//	**食べる【たべる】** 食べる: past
+//
+//	1. *(v1, vt)* to eat
+//	2. to live on (e.g. a salary); to live off
+func (s *server) describe(matches []jmdict.Match) string {
+	if s.limit > 0 && len(matches) > s.limit {
+		matches = matches[:s.limit]
+	}
+	var b strings.Builder
+	for i, m := range matches {
+		if i > 0 {
+			b.WriteString("\n\n---\n\n")
+		}
+		head, _ := m.Entry.Headword()
+		fmt.Fprintf(&b, "**%s**", head.String())
+		if len(m.Reasons) > 0 {
+			fmt.Fprintf(&b, " %s: %s", m.Word, strings.Join(m.Reasons, ", "))
+		}
+		b.WriteString("\n")
+		for j, sense := range m.Entry.Sense {
+			fmt.Fprintf(&b, "\n%d. ", j+1)
+			var tags []string
+			for _, p := range sense.Position {
+				tags = append(tags, string(p))
+			}
+			for _, misc := range sense.Misc {
+				tags = append(tags, string(misc))
+			}
+			if len(tags) > 0 {
+				fmt.Fprintf(&b, "*(%s)* ", strings.Join(tags, ", "))
+			}
+			b.WriteString(strings.Join(sense.Gloss, "; "))
+		}
+	}
+	return b.String()
+}
+
+// Returns the headwords starting with the Japanese text before the
+// cursor. The longest text with completions is used, so that after
+// 今日は食べ the completions are for 食べ.
+func (s *server) complete(line []rune, cursor, lineNo int) completionList {
+	list := completionList{Items: []completionItem{}}
+	lo := cursor
+	for lo > 0 && isJapanese(line[lo-1]) && cursor-lo < maxWord {
+		lo--
+	}
+	for start := lo; start < cursor; start++ {
+		prefix := string(line[start:cursor])
+		entries := s.index.Prefix(prefix)
+		if len(entries) == 0 {
+			continue
+		}
+		if len(entries) > maxCompletions {
+			entries = entries[:maxCompletions]
+			list.IsIncomplete = true
+		}
+		edit := lspRange{runePosition(line, start, lineNo), runePosition(line, cursor, lineNo)}
+		for _, e := range entries {
+			head, _ := e.Headword()
+			label := completionLabel(e, prefix, head)
+			var glosses []string
+			for _, sense := range e.Sense {
+				glosses = append(glosses, strings.Join(sense.Gloss, "; "))
+			}
+			list.Items = append(list.Items, completionItem{
+				Label:         label,
+				Kind:          1, // text
+				Detail:        head.String(),
+				Documentation: &markupContent{"markdown", strings.Join(glosses, "\n\n")},
+				TextEdit:      &textEdit{edit, label},
+			})
+		}
+		return list
+	}
+	return list
+}
+
+// Returns the form of e to complete prefix with: the headword if it
+// starts with prefix, or else the first keb or reb that does. Prefix
+// matching folds katakana to hiragana, so none may start with prefix
+// exactly, in which case the headword is used.
+func completionLabel(e *jmdict.Entry, prefix string, head jmdict.Pair) string {
+	if head.Kanji != nil && strings.HasPrefix(string(head.Kanji.Phrase), prefix) {
+		return string(head.Kanji.Phrase)
+	}
+	if head.Reading != nil && strings.HasPrefix(string(head.Reading.Phrase), prefix) {
+		return string(head.Reading.Phrase)
+	}
+	for _, k := range e.Kanji {
+		if strings.HasPrefix(string(k.Phrase), prefix) {
+			return string(k.Phrase)
+		}
+	}
+	for _, r := range e.Reading {
+		if strings.HasPrefix(string(r.Phrase), prefix) {
+			return string(r.Phrase)
+		}
+	}
+	return head.Headword()
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/0xfaded/jmdict"
+)
+
+// Returns a server looking up the sample dictionary, exchanging messages
+// through in and out.
+func newTestServer(t *testing.T, in string, out *bytes.Buffer) *server {
+	t.Helper()
+	dict, err := jmdict.Open("../../testdata/sample.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An entry without readings has no headword pair.
+	dict.Entries = append(dict.Entries, jmdict.Entry{
+		Id:    9000000,
+		Kanji: []jmdict.KEle{{Phrase: "某某"}},
+		Sense: []jmdict.Sense{{Gloss: []string{"so-and-so"}}},
+	})
+	return &server{
+		index: jmdict.NewIndex(dict),
+		limit: 5,
+		docs:  make(map[string]string),
+		in:    bufio.NewReader(strings.NewReader(in)),
+		out:   bufio.NewWriter(out),
+	}
+}
+
+func TestLineAt(t *testing.T) {
+	// 𠮷 is outside the Basic Multilingual Plane, so it is two UTF-16
+	// code units long.
+	text := "a𠮷食べる\r\nこれ"
+	tests := []struct {
+		pos    position
+		line   string
+		cursor int
+	}{
+		{position{0, 0}, "a𠮷食べる", 0},
+		{position{0, 1}, "a𠮷食べる", 1},
+		{position{0, 2}, "a𠮷食べる", 2}, // inside 𠮷
+		{position{0, 3}, "a𠮷食べる", 2},
+		{position{0, 4}, "a𠮷食べる", 3},
+		{position{0, 99}, "a𠮷食べる", 5},
+		{position{1, 1}, "これ", 1},
+		{position{2, 0}, "", 0},
+		{position{-1, 0}, "", 0},
+	}
+	for _, test := range tests {
+		line, cursor := lineAt(text, test.pos)
+		if string(line) != test.line || cursor != test.cursor {
+			t.Errorf("lineAt(%v) = %q, %d; want %q, %d", test.pos, string(line), cursor, test.line, test.cursor)
+		}
+	}
+
+	line, _ := lineAt(text, position{0, 0})
+	for i, want := range []int{0, 1, 3, 4, 5, 6} {
+		if got := runePosition(line, i, 0); got != (position{0, want}) {
+			t.Errorf("runePosition(%d) = %v, want character %d", i, got, want)
+		}
+	}
+}
+
+func TestHover(t *testing.T) {
+	s := newTestServer(t, "", &bytes.Buffer{})
+	tests := []struct {
+		line       string
+		cursor     int
+		start, end int // in UTF-16 code units
+		contents   []string
+	}{
+		// Every character of an inflected word finds the whole word.
+		{"今日は食べさせなかった。", 3, 3, 11, []string{"**食べる【たべる】** 食べる: causative, negative, past", "to eat"}},
+		{"今日は食べさせなかった。", 10, 3, 11, []string{"食べる【たべる】"}},
+		{"子供が食べる", 1, 0, 2, []string{"**こども**", "child"}},
+		{"子供が食べる", 4, 3, 6, []string{"**食べる【たべる】**\n"}},
+		// Ranges count UTF-16 code units.
+		{"𠮷食べる", 2, 2, 5, []string{"食べる【たべる】"}},
+		{"これ", 0, 0, 2, []string{"**これ**"}},
+		{"某某", 1, 0, 2, []string{"so-and-so"}},
+	}
+	for _, test := range tests {
+		line := []rune(test.line)
+		h := s.hover(line, test.cursor, 7)
+		if h == nil {
+			t.Errorf("hover(%s, %d) = nil", test.line, test.cursor)
+			continue
+		}
+		want := lspRange{position{7, test.start}, position{7, test.end}}
+		if h.Range != want {
+			t.Errorf("hover(%s, %d): range %v, want %v", test.line, test.cursor, h.Range, want)
+		}
+		for _, c := range test.contents {
+			if !strings.Contains(h.Contents.Value, c) {
+				t.Errorf("hover(%s, %d) = %q, want it to contain %q", test.line, test.cursor, h.Contents.Value, c)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		line   string
+		cursor int
+	}{
+		{"今日は食べる", 2}, // は starts no word
+		{"食べる。", 3},
+		{"eat", 1},
+		{"食べる", 3},
+	} {
+		if h := s.hover([]rune(test.line), test.cursor, 0); h != nil {
+			t.Errorf("hover(%s, %d) = %q, want nil", test.line, test.cursor, h.Contents.Value)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	s := newTestServer(t, "", &bytes.Buffer{})
+	var labels []string
+	list := s.complete([]rune("今日は食べ"), 5, 0)
+	for _, item := range list.Items {
+		labels = append(labels, item.Label)
+	}
+	if !reflect.DeepEqual(labels, []string{"食べる"}) {
+		t.Errorf("completions of 食べ = %q, want [食べる]", labels)
+	}
+	if item := list.Items[0]; item.Detail != "食べる【たべる】" ||
+		item.TextEdit.Range != (lspRange{position{0, 3}, position{0, 5}}) {
+		t.Errorf("completion = %+v", item)
+	}
+
+	list = s.complete([]rune("某"), 1, 0)
+	if len(list.Items) != 1 || list.Items[0].Label != "某某" || list.Items[0].Detail != "" {
+		t.Errorf("completions of 某 = %+v", list.Items)
+	}
+}
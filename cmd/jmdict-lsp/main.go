@@ -0,0 +1,271 @@
+// Command jmdict-lsp is a language server which looks up Japanese text
+// in a JMdict dictionary, for use by editors.
+//
+// Usage:
+//
+//	jmdict-lsp -dict JMdict_e.gz
+//
+// The dictionary may be JMdict XML or a binary snapshot written by
+// jmdict.WriteBinary, optionally compressed, and defaults to the JMDICT
+// environment variable. The server speaks JSON-RPC over standard input
+// and output as in the Language Server Protocol, and supports
+//
+//	textDocument/hover       the entries for the word under the cursor,
+//	                         found by segmenting the surrounding run of
+//	                         Japanese text, with inflections undone
+//	textDocument/completion  headwords starting with the Japanese text
+//	                         before the cursor
+//
+// Documents are synchronized in full. Positions are counted in UTF-16
+// code units, as the protocol requires.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/textproto"
+	"os"
+	"strconv"
+
+	"github.com/0xfaded/jmdict"
+)
+
+// JSON-RPC error codes.
+const (
+	parseError           = -32700
+	invalidParams        = -32602
+	methodNotFound       = -32601
+	serverNotInitialized = -32002
+)
+
+// A message is a JSON-RPC request, notification or response. Requests
+// have an ID, notifications do not.
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  interface{}      `json:"result,omitempty"`
+	Error   *rpcError        `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+type server struct {
+	index       *jmdict.Index
+	limit       int
+	docs        map[string]string // text of open documents by URI
+	initialized bool
+	shutdown    bool
+
+	in  *bufio.Reader
+	out *bufio.Writer
+}
+
+func main() {
+	path := flag.String("dict", os.Getenv("JMDICT"), "dictionary file (default $JMDICT)")
+	limit := flag.Int("limit", 5, "maximum entries shown on hover")
+	flag.Parse()
+	// Standard output carries the protocol, so logs go to standard error.
+	log.SetOutput(os.Stderr)
+	if *path == "" {
+		log.Fatal("jmdict-lsp: no dictionary: use -dict or set JMDICT")
+	}
+
+	dict, err := jmdict.Open(*path)
+	if err != nil {
+		log.Fatalf("jmdict-lsp: %s: %v", *path, err)
+	}
+	log.Printf("jmdict-lsp: loaded %d entries from %s", len(dict.Entries), *path)
+
+	s := &server{
+		index: jmdict.NewIndex(dict),
+		limit: *limit,
+		docs:  make(map[string]string),
+		in:    bufio.NewReader(os.Stdin),
+		out:   bufio.NewWriter(os.Stdout),
+	}
+	os.Exit(s.run())
+}
+
+// Serves requests until the client exits, returning the exit status: 0
+// if the client asked for shutdown first, and 1 otherwise.
+func (s *server) run() int {
+	for {
+		msg, err := s.read()
+		if err == io.EOF {
+			return 1
+		}
+		if err != nil {
+			var rerr *rpcError
+			if errors.As(err, &rerr) {
+				s.reply(nil, nil, rerr)
+				continue
+			}
+			log.Printf("jmdict-lsp: %v", err)
+			return 1
+		}
+		if msg.Method == "exit" {
+			if s.shutdown {
+				return 0
+			}
+			return 1
+		}
+		result, rerr := s.handle(msg)
+		if msg.ID != nil {
+			s.reply(msg.ID, result, rerr)
+		} else if rerr != nil {
+			log.Printf("jmdict-lsp: %s: %v", msg.Method, rerr)
+		}
+	}
+}
+
+// Reads a message framed by a Content-Length header. A malformed body
+// gives an *rpcError, to be reported to the client.
+func (s *server) read() (*message, error) {
+	header, err := textproto.NewReader(s.in).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("bad Content-Length %q", header.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return nil, io.EOF
+	}
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, &rpcError{parseError, err.Error()}
+	}
+	return &msg, nil
+}
+
+func (s *server) reply(id *json.RawMessage, result interface{}, rerr *rpcError) {
+	msg := message{JSONRPC: "2.0", ID: id, Error: rerr}
+	if id == nil {
+		// Errors without a request, for instance parse errors, have a
+		// null ID.
+		null := json.RawMessage("null")
+		msg.ID = &null
+	}
+	if rerr == nil {
+		if result == nil {
+			// A successful response must have a result, even if null.
+			result = json.RawMessage("null")
+		}
+		msg.Result = result
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("jmdict-lsp: encoding response: %v", err)
+		return
+	}
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n", len(body))
+	s.out.Write(body)
+	if err := s.out.Flush(); err != nil {
+		log.Printf("jmdict-lsp: writing response: %v", err)
+	}
+}
+
+// Handles a request or notification, returning the result of a request.
+func (s *server) handle(msg *message) (interface{}, *rpcError) {
+	if !s.initialized && msg.Method != "initialize" {
+		if msg.ID == nil {
+			return nil, nil
+		}
+		return nil, &rpcError{serverNotInitialized, "server not initialized"}
+	}
+
+	switch msg.Method {
+	case "initialize":
+		s.initialized = true
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   1, // full
+				"hoverProvider":      true,
+				"completionProvider": map[string]interface{}{},
+			},
+			"serverInfo": map[string]string{"name": "jmdict-lsp"},
+		}, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params struct {
+			TextDocument struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"textDocument"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &rpcError{invalidParams, err.Error()}
+		}
+		s.docs[params.TextDocument.URI] = params.TextDocument.Text
+		return nil, nil
+	case "textDocument/didChange":
+		var params struct {
+			TextDocument   documentID `json:"textDocument"`
+			ContentChanges []struct {
+				Text string `json:"text"`
+			} `json:"contentChanges"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &rpcError{invalidParams, err.Error()}
+		}
+		// With full synchronization the last change holds the whole text.
+		if n := len(params.ContentChanges); n > 0 {
+			s.docs[params.TextDocument.URI] = params.ContentChanges[n-1].Text
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var params struct {
+			TextDocument documentID `json:"textDocument"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &rpcError{invalidParams, err.Error()}
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, nil
+
+	case "textDocument/hover", "textDocument/completion":
+		var params positionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &rpcError{invalidParams, err.Error()}
+		}
+		text, ok := s.docs[params.TextDocument.URI]
+		if !ok {
+			return nil, &rpcError{invalidParams, "document not open: " + params.TextDocument.URI}
+		}
+		line, cursor := lineAt(text, params.Position)
+		if msg.Method == "textDocument/hover" {
+			return s.hover(line, cursor, params.Position.Line), nil
+		}
+		return s.complete(line, cursor, params.Position.Line), nil
+	}
+
+	if msg.ID == nil {
+		// Other notifications, such as $/cancelRequest, may be ignored.
+		return nil, nil
+	}
+	return nil, &rpcError{methodNotFound, "method not found: " + msg.Method}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Frames a JSON-RPC message with a Content-Length header.
+func frame(body string) string {
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func TestReadFraming(t *testing.T) {
+	in := frame(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`) +
+		"Content-Type: application/vscode-jsonrpc; charset=utf-8\r\n" +
+		frame(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"a","text":"食べる"}}}`) +
+		frame(`{"jsonrpc":`) +
+		"Content-Length: 10\r\n\r\n{}"
+	s := newTestServer(t, in, &bytes.Buffer{})
+
+	msg, err := s.read()
+	if err != nil || msg.Method != "initialize" || string(*msg.ID) != "1" {
+		t.Fatalf("first message = %+v, %v", msg, err)
+	}
+	// Other headers are allowed, and the body is read by its length in
+	// bytes rather than characters.
+	msg, err = s.read()
+	if err != nil || msg.Method != "textDocument/didOpen" || msg.ID != nil {
+		t.Fatalf("second message = %+v, %v", msg, err)
+	}
+	var rerr *rpcError
+	if _, err = s.read(); !errors.As(err, &rerr) || rerr.Code != parseError {
+		t.Errorf("malformed body: %v, want a parse error", err)
+	}
+	if _, err = s.read(); err != io.EOF {
+		t.Errorf("truncated body: %v, want io.EOF", err)
+	}
+	if _, err = s.read(); err != io.EOF {
+		t.Errorf("end of input: %v, want io.EOF", err)
+	}
+
+	for _, header := range []string{"Content-Length: x\r\n\r\n{}", "Content-Type: text/plain\r\n\r\n{}"} {
+		s := newTestServer(t, header, &bytes.Buffer{})
+		if _, err := s.read(); err == nil || errors.As(err, &rerr) || err == io.EOF {
+			t.Errorf("header %q: %v, want an error ending the session", header, err)
+		}
+	}
+}
+
+// Reads the framed responses written by the server.
+func readResponses(t *testing.T, out *bytes.Buffer) []map[string]json.RawMessage {
+	t.Helper()
+	r := bufio.NewReader(out)
+	var msgs []map[string]json.RawMessage
+	for {
+		header, err := textproto.NewReader(r).ReadMIMEHeader()
+		if err == io.EOF {
+			return msgs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		length, err := strconv.Atoi(header.Get("Content-Length"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body := make([]byte, length)
+		if _, err := io.ReadFull(r, body); err != nil {
+			t.Fatal(err)
+		}
+		var msg map[string]json.RawMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatalf("response %s: %v", body, err)
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func TestSession(t *testing.T) {
+	in := frame(`{"jsonrpc":"2.0","id":1,"method":"textDocument/hover","params":{}}`) +
+		frame(`{"jsonrpc":"2.0","id":2,"method":"initialize","params":{}}`) +
+		frame(`{"jsonrpc":"2.0","method":"initialized","params":{}}`) +
+		frame(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"file:///a.txt","text":"𠮷\n今日は食べた"}}}`) +
+		frame(`{"jsonrpc":"2.0","id":3,"method":"textDocument/hover","params":{"textDocument":{"uri":"file:///a.txt"},"position":{"line":1,"character":4}}}`) +
+		frame(`{"jsonrpc":"2.0","id":4,"method":"textDocument/hover","params":{"textDocument":{"uri":"file:///b.txt"},"position":{"line":0,"character":0}}}`) +
+		frame(`not json`) +
+		frame(`{"jsonrpc":"2.0","id":5,"method":"workspace/symbol","params":{}}`) +
+		frame(`{"jsonrpc":"2.0","id":6,"method":"shutdown"}`) +
+		frame(`{"jsonrpc":"2.0","method":"exit"}`)
+	var out bytes.Buffer
+	s := newTestServer(t, in, &out)
+	if status := s.run(); status != 0 {
+		t.Errorf("exit status %d after shutdown, want 0", status)
+	}
+
+	msgs := readResponses(t, &out)
+	want := []struct {
+		id     string
+		result string // substring of the result
+		code   int    // error code, if an error is expected
+	}{
+		{"1", "", serverNotInitialized},
+		{"2", `"hoverProvider":true`, 0},
+		{"3", `"range":{"start":{"line":1,"character":3},"end":{"line":1,"character":6}}`, 0},
+		{"4", "", invalidParams},
+		{"null", "", parseError},
+		{"5", "", methodNotFound},
+		{"6", "null", 0},
+	}
+	if len(msgs) != len(want) {
+		t.Fatalf("%d responses, want %d", len(msgs), len(want))
+	}
+	for i, w := range want {
+		msg := msgs[i]
+		if string(msg["id"]) != w.id {
+			t.Errorf("response %d: id %s, want %s", i, msg["id"], w.id)
+		}
+		if w.code != 0 {
+			var rerr rpcError
+			if err := json.Unmarshal(msg["error"], &rerr); err != nil || rerr.Code != w.code {
+				t.Errorf("response %d: error %s, want code %d", i, msg["error"], w.code)
+			}
+			continue
+		}
+		if result, ok := msg["result"]; !ok || !strings.Contains(string(result), w.result) {
+			t.Errorf("response %d: result %s, want it to contain %s", i, result, w.result)
+		}
+	}
+}
+
+func TestExitWithoutShutdown(t *testing.T) {
+	s := newTestServer(t, frame(`{"jsonrpc":"2.0","method":"exit"}`), &bytes.Buffer{})
+	if status := s.run(); status != 1 {
+		t.Errorf("exit status %d without shutdown, want 1", status)
+	}
+}
@@ -0,0 +1,252 @@
+// Command jmdict-server serves lookups against a JMdict dictionary as a
+// JSON REST API.
+//
+// Usage:
+//
+//	jmdict-server -dict JMdict_e.gz [-addr :8080]
+//
+// The dictionary may be JMdict XML or a binary snapshot written by
+// jmdict.WriteBinary, optionally compressed. The endpoints are
+//
+//	GET /entry/{seq}                   the entry with the given ent_seq
+//	GET /search?q=&mode=               entries matching q, where mode is
+//	                                   exact (the default), prefix, gloss
+//	                                   or romaji
+//	GET /kanji/{char}                  entries with a keb containing char
+//	GET /healthz                       liveness and dictionary size
+//
+// Entries are encoded as by jmdict.Entry.MarshalJSON. The search and
+// kanji endpoints return pages of results, selected with the offset and
+// limit parameters:
+//
+//	{"total":12,"offset":0,"limit":20,"entries":[...]}
+//
+// Successful responses carry an ETag derived from the dictionary's
+// content hash, and requests with a matching If-None-Match header
+// receive 304 Not Modified instead. Error responses carry no ETag.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/0xfaded/jmdict"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type server struct {
+	index *jmdict.Index
+	etag  string
+}
+
+type page struct {
+	Query   string          `json:"query,omitempty"`
+	Mode    string          `json:"mode,omitempty"`
+	Total   int             `json:"total"`
+	Offset  int             `json:"offset"`
+	Limit   int             `json:"limit"`
+	Entries []*jmdict.Entry `json:"entries"`
+}
+
+func main() {
+	path := flag.String("dict", "", "dictionary file (XML or binary snapshot, optionally compressed)")
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+	if *path == "" {
+		flag.Usage()
+		log.Fatal("jmdict-server: -dict is required")
+	}
+
+	dict, err := jmdict.Open(*path)
+	if err != nil {
+		log.Fatalf("jmdict-server: %s: %v", *path, err)
+	}
+	s := newServer(dict)
+	log.Printf("jmdict-server: loaded %d entries from %s", len(dict.Entries), *path)
+	log.Printf("jmdict-server: listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, s.handler()))
+}
+
+func newServer(dict jmdict.JMDict) *server {
+	return &server{
+		index: jmdict.NewIndex(dict),
+		etag:  `"` + jmdict.ContentHash(dict).String()[:32] + `"`,
+	}
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/entry/", s.cached(s.entry))
+	mux.HandleFunc("/search", s.cached(s.search))
+	mux.HandleFunc("/kanji/", s.cached(s.kanji))
+	mux.HandleFunc("/healthz", s.healthz)
+	return mux
+}
+
+// Wraps a handler of dictionary content with method checks and ETag
+// validation. Since the dictionary never changes while the server runs,
+// its content hash serves as the ETag of every successful response.
+func (s *server) cached(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		h(&etagWriter{ResponseWriter: w, etag: s.etag,
+			match: matchETag(r.Header.Get("If-None-Match"), s.etag)}, r)
+	}
+}
+
+// An etagWriter adds the ETag to successful responses, and turns them
+// into 304 Not Modified when the request's If-None-Match matches it.
+// Other responses are passed on unchanged, so that errors are neither
+// tagged nor hidden by a cached response.
+type etagWriter struct {
+	http.ResponseWriter
+	etag    string
+	match   bool // If-None-Match lists etag
+	discard bool // the body of a 304 response is dropped
+}
+
+func (w *etagWriter) WriteHeader(status int) {
+	if status == http.StatusOK {
+		w.Header().Set("ETag", w.etag)
+		w.Header().Set("Cache-Control", "no-cache")
+		if w.match {
+			w.Header().Del("Content-Type")
+			status, w.discard = http.StatusNotModified, true
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	if w.discard {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Reports whether an If-None-Match header lists etag.
+func matchETag(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *server) entry(w http.ResponseWriter, r *http.Request) {
+	seq, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/entry/"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "ent_seq must be a number")
+		return
+	}
+	e, ok := s.index.Get(jmdict.EntSeq(seq))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no entry %d", seq))
+		return
+	}
+	writeJSON(w, http.StatusOK, e)
+}
+
+func (s *server) search(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		writeError(w, http.StatusBadRequest, "q is required")
+		return
+	}
+	mode := r.URL.Query().Get("mode")
+	var entries []*jmdict.Entry
+	switch mode {
+	case "", "exact":
+		mode = "exact"
+		entries = s.index.Exact(q)
+	case "prefix":
+		entries = s.index.Prefix(q)
+	case "gloss":
+		entries = s.index.Gloss(q)
+	case "romaji":
+		entries = s.index.Romaji(q)
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown mode %q", mode))
+		return
+	}
+	s.writePage(w, r, page{Query: q, Mode: mode}, entries)
+}
+
+func (s *server) kanji(w http.ResponseWriter, r *http.Request) {
+	char := strings.TrimPrefix(r.URL.Path, "/kanji/")
+	c, size := utf8.DecodeRuneInString(char)
+	if char == "" || size != len(char) || c == utf8.RuneError {
+		writeError(w, http.StatusBadRequest, "expected a single character")
+		return
+	}
+	s.writePage(w, r, page{Query: char}, s.index.Kanji(c))
+}
+
+func (s *server) healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":  "ok",
+		"entries": len(s.index.Dict().Entries),
+	})
+}
+
+// Writes the page of entries selected by the offset and limit query
+// parameters.
+func (s *server) writePage(w http.ResponseWriter, r *http.Request, p page, entries []*jmdict.Entry) {
+	var err error
+	p.Offset, p.Limit = 0, defaultLimit
+	if v := r.URL.Query().Get("offset"); v != "" {
+		if p.Offset, err = strconv.Atoi(v); err != nil || p.Offset < 0 {
+			writeError(w, http.StatusBadRequest, "offset must be a non-negative number")
+			return
+		}
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if p.Limit, err = strconv.Atoi(v); err != nil || p.Limit < 1 || p.Limit > maxLimit {
+			writeError(w, http.StatusBadRequest,
+				fmt.Sprintf("limit must be between 1 and %d", maxLimit))
+			return
+		}
+	}
+
+	p.Total = len(entries)
+	if p.Offset > len(entries) {
+		p.Offset = len(entries)
+	}
+	end := p.Offset + p.Limit
+	if end > len(entries) {
+		end = len(entries)
+	}
+	// An empty page has an empty list of entries rather than null.
+	p.Entries = append([]*jmdict.Entry{}, entries[p.Offset:end]...)
+	writeJSON(w, http.StatusOK, p)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		log.Printf("jmdict-server: writing response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/0xfaded/jmdict"
+)
+
+func newTestServer(t *testing.T) *server {
+	t.Helper()
+	dict, err := jmdict.Open("../../testdata/sample.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newServer(dict)
+}
+
+// Serves a GET of target, with the given If-None-Match header if any.
+func get(s *server, target, ifNoneMatch string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	if ifNoneMatch != "" {
+		r.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	s.handler().ServeHTTP(w, r)
+	return w
+}
+
+type testPage struct {
+	Total   int
+	Offset  int
+	Limit   int
+	Entries []struct {
+		Seq jmdict.EntSeq `json:"ent_seq"`
+	}
+}
+
+func decodePage(t *testing.T, target string, w *httptest.ResponseRecorder) ([]jmdict.EntSeq, testPage) {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s: status %d: %s", target, w.Code, w.Body)
+	}
+	var p testPage
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("GET %s: %v", target, err)
+	}
+	var seqs []jmdict.EntSeq
+	for _, e := range p.Entries {
+		seqs = append(seqs, e.Seq)
+	}
+	return seqs, p
+}
+
+func TestSearch(t *testing.T) {
+	s := newTestServer(t)
+	tests := []struct {
+		target string
+		seqs   []jmdict.EntSeq
+	}{
+		{"/search?q=たべる", []jmdict.EntSeq{1358280}},
+		{"/search?q=タベル&mode=exact", []jmdict.EntSeq{1358280}},
+		{"/search?q=た&mode=prefix", []jmdict.EntSeq{1280640, 1358280}},
+		{"/search?q=eat&mode=gloss", []jmdict.EntSeq{1358280}},
+		{"/search?q=kodomo&mode=romaji", []jmdict.EntSeq{1591900}},
+		{"/search?q=zzz&mode=romaji", nil},
+		{"/kanji/供", []jmdict.EntSeq{1591900}},
+	}
+	for _, test := range tests {
+		seqs, p := decodePage(t, test.target, get(s, test.target, ""))
+		if !reflect.DeepEqual(seqs, test.seqs) || p.Total != len(test.seqs) {
+			t.Errorf("GET %s = %v of %d, want %v", test.target, seqs, p.Total, test.seqs)
+		}
+	}
+
+	for _, target := range []string{
+		"/search",
+		"/search?q=+",
+		"/search?q=た&mode=fuzzy",
+		"/kanji/",
+		"/kanji/食べ",
+	} {
+		if w := get(s, target, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: status %d, want 400", target, w.Code)
+		}
+	}
+}
+
+func TestSearchEmpty(t *testing.T) {
+	s := newTestServer(t)
+	for _, target := range []string{"/search?q=ない", "/search?q=た&mode=prefix&offset=2"} {
+		w := get(s, target, "")
+		if !strings.Contains(w.Body.String(), `"entries":[]`) {
+			t.Errorf("GET %s = %s, want an empty entries list", target, w.Body)
+		}
+	}
+}
+
+func TestPagination(t *testing.T) {
+	s := newTestServer(t)
+	tests := []struct {
+		target        string
+		seqs          []jmdict.EntSeq
+		offset, limit int
+	}{
+		{"/search?q=た&mode=prefix", []jmdict.EntSeq{1280640, 1358280}, 0, defaultLimit},
+		{"/search?q=た&mode=prefix&limit=1", []jmdict.EntSeq{1280640}, 0, 1},
+		{"/search?q=た&mode=prefix&offset=1&limit=1", []jmdict.EntSeq{1358280}, 1, 1},
+		{"/search?q=た&mode=prefix&offset=9&limit=100", nil, 2, 100},
+	}
+	for _, test := range tests {
+		seqs, p := decodePage(t, test.target, get(s, test.target, ""))
+		if !reflect.DeepEqual(seqs, test.seqs) || p.Total != 2 || p.Offset != test.offset || p.Limit != test.limit {
+			t.Errorf("GET %s = %v, %+v; want %v at offset %d, limit %d",
+				test.target, seqs, p, test.seqs, test.offset, test.limit)
+		}
+	}
+
+	for _, query := range []string{"offset=-1", "offset=x", "limit=0", "limit=101", "limit=x"} {
+		target := "/search?q=た&mode=prefix&" + query
+		if w := get(s, target, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: status %d, want 400", target, w.Code)
+		}
+	}
+}
+
+func TestEntry(t *testing.T) {
+	s := newTestServer(t)
+	w := get(s, "/entry/1358280", "")
+	var e jmdict.Entry
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &e) != nil || e.Kanji[0].Phrase != "食べる" {
+		t.Errorf("GET /entry/1358280: status %d: %s", w.Code, w.Body)
+	}
+
+	for target, status := range map[string]int{"/entry/1": http.StatusNotFound, "/entry/x": http.StatusBadRequest} {
+		w := get(s, target, "")
+		if w.Code != status {
+			t.Errorf("GET %s: status %d, want %d", target, w.Code, status)
+		}
+		if tag := w.Header().Get("ETag"); tag != "" {
+			t.Errorf("GET %s: error response has ETag %s", target, tag)
+		}
+	}
+}
+
+func TestETag(t *testing.T) {
+	s := newTestServer(t)
+	w := get(s, "/entry/1358280", "")
+	etag := w.Header().Get("ETag")
+	if etag == "" || etag != s.etag {
+		t.Fatalf("ETag = %q, want %q", etag, s.etag)
+	}
+
+	for _, header := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		w := get(s, "/search?q=たべる", header)
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("ETag") != etag {
+			t.Errorf("If-None-Match %s: status %d, ETag %q, %d bytes; want 304 with the ETag and no body",
+				header, w.Code, w.Header().Get("ETag"), w.Body.Len())
+		}
+	}
+	if w := get(s, "/search?q=たべる", `"other"`); w.Code != http.StatusOK {
+		t.Errorf("If-None-Match \"other\": status %d, want 200", w.Code)
+	}
+
+	// Errors are reported whatever the client has cached.
+	if w := get(s, "/entry/1", etag); w.Code != http.StatusNotFound {
+		t.Errorf("If-None-Match on a missing entry: status %d, want 404", w.Code)
+	}
+	if w := get(s, "/search?q=た&limit=0", "*"); w.Code != http.StatusBadRequest {
+		t.Errorf("If-None-Match on a bad request: status %d, want 400", w.Code)
+	}
+}
+
+func TestMethod(t *testing.T) {
+	s := newTestServer(t)
+	w := httptest.NewRecorder()
+	s.handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/entry/1358280", nil))
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "GET, HEAD" {
+		t.Errorf("POST: status %d, Allow %q", w.Code, w.Header().Get("Allow"))
+	}
+}
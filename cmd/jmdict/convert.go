@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/0xfaded/jmdict"
+)
+
+// Name of the database/sql driver used for SQLite output. It is set by
+// sqlite.go when built with the sqlite tag, since the driver is a
+// dependency which not every build wants.
+var sqliteDriver string
+
+// Runs "jmdict convert", returning the exit status.
+func convert(args []string) int {
+	flags := flag.NewFlagSet("jmdict convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	to := flags.String("to", "", "output format: jsonl, edict, sqlite, yomitan, stardict or binary")
+	out := flags.String("o", "-", "output file, or - for standard output; the base path for stardict")
+	filter := flags.String("filter", "", "keep only entries matching this filter expression")
+	title := flags.String("title", "JMdict", "dictionary title for yomitan and stardict")
+	revision := flags.String("revision", time.Now().Format("2006-01-02"), "dictionary revision for yomitan")
+	compress := flags.Bool("compress", true, "write the stardict definitions with dictzip")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: jmdict convert -to format [flags] [input]\n\n"+
+			"The input is JMdict XML, EDICT2 starting with its header line or a\n"+
+			"binary snapshot, optionally compressed, and is read from standard\n"+
+			"input if not given. SQLite output needs the command to be built\n"+
+			"with -tags sqlite, which links the modernc.org/sqlite driver.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return parseStatus(err)
+	}
+
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+	switch *to {
+	case "jsonl", "edict", "yomitan", "binary":
+	case "sqlite", "stardict":
+		if *out == "-" {
+			return fail("convert: -to %s needs an output file", *to)
+		}
+	case "":
+		flags.Usage()
+		return 2
+	default:
+		return fail("convert: unknown output format %q", *to)
+	}
+	if *to == "sqlite" && sqliteDriver == "" {
+		return fail("convert: built without SQLite support; rebuild with -tags sqlite")
+	}
+	var keep jmdict.Filter
+	if *filter != "" {
+		f, err := jmdict.ParseFilter(*filter)
+		if err != nil {
+			return fail("convert: %v", err)
+		}
+		keep = f
+	}
+
+	var dict jmdict.JMDict
+	var err error
+	if in := flags.Arg(0); in != "" && in != "-" {
+		dict, err = jmdict.Open(in)
+	} else {
+		dict, err = jmdict.ReadAuto(stdin)
+	}
+	if err != nil {
+		return fail("convert: reading input: %v", err)
+	}
+	if keep != nil {
+		dict = dict.Subset(keep)
+	}
+
+	switch *to {
+	case "sqlite":
+		err = writeSQLite(*out, dict)
+	case "stardict":
+		err = jmdict.WriteStarDict(*out, dict, jmdict.StarDictInfo{
+			BookName:    *title,
+			Description: "Converted from JMdict, copyright the Electronic Dictionary Research and Development Group.",
+			Date:        time.Now().Format("2006-01-02"),
+			Compress:    *compress,
+		})
+	default:
+		err = writeOutput(*out, func(w io.Writer) error {
+			switch *to {
+			case "jsonl":
+				return jmdict.WriteJSONL(w, dict)
+			case "edict":
+				return jmdict.WriteEDICT2(w, dict)
+			case "yomitan":
+				return jmdict.WriteYomitan(w, dict, jmdict.YomitanIndex{
+					Title:       *title,
+					Revision:    *revision,
+					Sequenced:   true,
+					Format:      3,
+					Attribution: "JMdict, copyright the Electronic Dictionary Research and Development Group.",
+				})
+			default:
+				return jmdict.WriteBinary(w, dict)
+			}
+		})
+	}
+	if err != nil {
+		return fail("convert: writing %s: %v", *to, err)
+	}
+	return 0
+}
+
+// Calls write with the output file, or standard output for "-".
+func writeOutput(path string, write func(io.Writer) error) error {
+	if path == "-" {
+		w := bufio.NewWriter(stdout)
+		if err := write(w); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// Writes the database to path, replacing any file there as writeOutput
+// does, since jmdict.WriteSQLite creates its tables afresh.
+func writeSQLite(path string, dict jmdict.JMDict) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	db, err := sql.Open(sqliteDriver, path)
+	if err != nil {
+		return err
+	}
+	if err := jmdict.WriteSQLite(db, dict); err != nil {
+		db.Close()
+		os.Remove(path)
+		return err
+	}
+	return db.Close()
+}
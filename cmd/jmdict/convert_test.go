@@ -0,0 +1,207 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/0xfaded/jmdict"
+)
+
+const samplePath = "../../testdata/sample.xml"
+
+func readFile(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func entrySeqs(dict jmdict.JMDict) []jmdict.EntSeq {
+	seqs := make([]jmdict.EntSeq, len(dict.Entries))
+	for i := range dict.Entries {
+		seqs[i] = dict.Entries[i].Id
+	}
+	return seqs
+}
+
+func TestConvertBinary(t *testing.T) {
+	want, err := jmdict.Open(samplePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "jmdict.bin")
+	if status, _, errOut := runCommand(t, "", "convert", "-to", "binary", "-o", out, samplePath); status != 0 {
+		t.Fatalf("status %d: %s", status, errOut)
+	}
+	if got, err := jmdict.Open(out); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("binary round trip gave %d entries, error %v", len(got.Entries), err)
+	}
+
+	// From standard input to standard output, converting the snapshot.
+	status, stdout, errOut := runCommand(t, string(readFile(t, out)), "convert", "-to", "binary")
+	if status != 0 {
+		t.Fatalf("status %d: %s", status, errOut)
+	}
+	if got, err := jmdict.ReadBinary(strings.NewReader(stdout)); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("binary from standard input gave %d entries, error %v", len(got.Entries), err)
+	}
+}
+
+func TestConvertFilter(t *testing.T) {
+	dict, err := jmdict.Open(samplePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := jmdict.ParseFilter("pri:nf<=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := dict.Subset(f)
+
+	status, stdout, errOut := runCommand(t, "", "convert", "-to", "binary", "-filter", "pri:nf<=2", samplePath)
+	if status != 0 {
+		t.Fatalf("status %d: %s", status, errOut)
+	}
+	got, err := jmdict.ReadBinary(strings.NewReader(stdout))
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("filtered entries %v, error %v, want %v", entrySeqs(got), err, entrySeqs(want))
+	}
+	if seqs := entrySeqs(got); !reflect.DeepEqual(seqs, []jmdict.EntSeq{1628530, 1578850, 1280640}) {
+		t.Errorf("filtered entries %v", seqs)
+	}
+}
+
+func TestConvertEDICT(t *testing.T) {
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first.edict"), filepath.Join(dir, "second.edict")
+	if status, _, errOut := runCommand(t, "", "convert", "-to", "edict", "-o", first, samplePath); status != 0 {
+		t.Fatalf("status %d: %s", status, errOut)
+	}
+	if status, _, errOut := runCommand(t, "", "convert", "-to", "edict", "-o", second, first); status != 0 {
+		t.Fatalf("status %d: %s", status, errOut)
+	}
+	text := readFile(t, first)
+	if !bytes.Equal(readFile(t, second), text) {
+		t.Errorf("EDICT2 changed on conversion:\n%s\nthen:\n%s", text, readFile(t, second))
+	}
+	if n := bytes.Count(text, []byte("/EntL")); n != 8 {
+		t.Errorf("%d entries written, want 8:\n%s", n, text)
+	}
+}
+
+func TestConvertJSONL(t *testing.T) {
+	dict, err := jmdict.Open(samplePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want bytes.Buffer
+	if err := jmdict.WriteJSONL(&want, dict); err != nil {
+		t.Fatal(err)
+	}
+	status, stdout, errOut := runCommand(t, string(readFile(t, samplePath)), "convert", "-to", "jsonl", "-")
+	if status != 0 || stdout != want.String() {
+		t.Fatalf("status %d: %s\noutput:\n%s", status, errOut, stdout)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(stdout, "\n"), "\n") {
+		if !json.Valid([]byte(line)) {
+			t.Errorf("invalid line %s", line)
+		}
+	}
+}
+
+func TestConvertYomitan(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "jmdict.zip")
+	status, _, errOut := runCommand(t, "", "convert", "-to", "yomitan", "-o", out,
+		"-title", "Sample", "-revision", "r1", samplePath)
+	if status != 0 {
+		t.Fatalf("status %d: %s", status, errOut)
+	}
+	z, err := zip.OpenReader(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer z.Close()
+	f, err := z.Open("index.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var index struct {
+		Title     string `json:"title"`
+		Revision  string `json:"revision"`
+		Sequenced bool   `json:"sequenced"`
+	}
+	if err := json.NewDecoder(f).Decode(&index); err != nil {
+		t.Fatal(err)
+	}
+	if index.Title != "Sample" || index.Revision != "r1" || !index.Sequenced {
+		t.Errorf("index %+v", index)
+	}
+}
+
+func TestConvertStarDict(t *testing.T) {
+	dir := t.TempDir()
+	for _, compress := range []bool{true, false} {
+		base := filepath.Join(dir, "sample")
+		status, _, errOut := runCommand(t, "", "convert", "-to", "stardict", "-o", base,
+			"-title", "Sample", "-compress="+strconv.FormatBool(compress), samplePath)
+		if status != 0 {
+			t.Fatalf("status %d: %s", status, errOut)
+		}
+		ifo := string(readFile(t, base+".ifo"))
+		if !strings.Contains(ifo, "bookname=Sample\n") || !strings.Contains(ifo, "wordcount=") {
+			t.Errorf(".ifo:\n%s", ifo)
+		}
+		dict := base + ".dict"
+		if compress {
+			dict += ".dz"
+		}
+		if _, err := os.Stat(dict); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"convert", samplePath}, "usage: jmdict convert"},
+		{[]string{"convert", "-to", "xml", samplePath}, `jmdict: convert: unknown output format "xml"`},
+		{[]string{"convert", "-to", "stardict", samplePath}, "jmdict: convert: -to stardict needs an output file"},
+		{[]string{"convert", "-to", "sqlite", samplePath}, "jmdict: convert: -to sqlite needs an output file"},
+		{[]string{"convert", "-to", "jsonl", "-filter", "foo:bar", samplePath}, "jmdict: convert: "},
+		{[]string{"convert", "-to", "jsonl", samplePath, samplePath}, "usage: jmdict convert"},
+		{[]string{"convert", "-to", "jsonl", filepath.Join(t.TempDir(), "missing")}, "jmdict: convert: reading input: "},
+		{[]string{"convert", "-to", "jsonl", "-o", filepath.Join(t.TempDir(), "no", "such"), samplePath},
+			"jmdict: convert: writing jsonl: "},
+		{[]string{"convert", "-compress=maybe"}, `invalid boolean value "maybe" for -compress`},
+	}
+	for _, test := range tests {
+		status, stdout, errOut := runCommand(t, "", test.args...)
+		if status != 2 || stdout != "" || !strings.Contains(errOut, test.err) {
+			t.Errorf("%q: status %d, error output %q, want 2 and %q", test.args, status, errOut, test.err)
+		}
+	}
+
+	if status, _, _ := runCommand(t, "", "convert", "-h"); status != 0 {
+		t.Errorf("convert -h: status %d, want 0", status)
+	}
+	if sqliteDriver == "" {
+		out := filepath.Join(t.TempDir(), "jmdict.db")
+		status, _, errOut := runCommand(t, "", "convert", "-to", "sqlite", "-o", out, samplePath)
+		if want := "built without SQLite support"; status != 2 || !strings.Contains(errOut, want) {
+			t.Errorf("sqlite without the driver: status %d, error output %q", status, errOut)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+// Command jmdict looks up words in a JMdict dictionary.
+//
+// Usage:
+//
+//	jmdict [flags] word...
+//	jmdict [flags] -stdin < words
+//	jmdict convert -to format [flags] [input]
+//
+// The dictionary is given by -dict or the JMDICT environment variable,
+// and may be JMdict XML or a binary snapshot written by
+// jmdict.WriteBinary, optionally compressed. The flags are
+//
+//	-mode exact|prefix|gloss|romaji
+//	    how to match the query against the dictionary (default exact)
+//	-deinflect
+//	    also look up the dictionary forms of inflected words in exact and
+//	    romaji modes (default true)
+//	-format text|json|edict
+//	    write entries as text with expanded tags, as JSON lines, or as
+//	    EDICT2 lines (default text)
+//	-limit n
+//	    write at most n entries per query, or all if n is 0 (default 10)
+//	-stdin
+//	    read queries from standard input, one per line
+//
+// The exit status is 1 if some query found nothing.
+//
+// The convert subcommand reads JMdict XML, EDICT2 starting with its
+// header line or a binary snapshot, optionally compressed, and writes it
+// as JSON lines (jsonl), EDICT2 (edict), an SQLite database (sqlite), a
+// Yomitan dictionary (yomitan), a StarDict dictionary (stardict) or a
+// binary snapshot (binary). Entries may be selected with -filter, using
+// the syntax of jmdict.ParseFilter. An existing output file is replaced.
+// SQLite output needs the command to be built with -tags sqlite, which
+// links the pure Go modernc.org/sqlite driver:
+//
+//	go build -tags sqlite ./cmd/jmdict
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/0xfaded/jmdict"
+)
+
+// Standard streams, replaced by tests.
+var (
+	stdin  io.Reader = os.Stdin
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+type lookup struct {
+	index     *jmdict.Index
+	mode      string
+	deinflect bool
+	format    string
+	limit     int
+	out       *bufio.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) > 0 && args[0] == "convert" {
+		return convert(args[1:])
+	}
+	flags := flag.NewFlagSet("jmdict", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	path := flags.String("dict", os.Getenv("JMDICT"), "dictionary file (default $JMDICT)")
+	mode := flags.String("mode", "exact", "match mode: exact, prefix, gloss or romaji")
+	deinflect := flags.Bool("deinflect", true, "look up dictionary forms of inflected words")
+	format := flags.String("format", "text", "output format: text, json or edict")
+	limit := flags.Int("limit", 10, "maximum entries per query, 0 for all")
+	readStdin := flags.Bool("stdin", false, "read queries from standard input, one per line")
+	if err := flags.Parse(args); err != nil {
+		return parseStatus(err)
+	}
+
+	switch *mode {
+	case "exact", "prefix", "gloss", "romaji":
+	default:
+		return fail("unknown mode %q", *mode)
+	}
+	switch *format {
+	case "text", "json", "edict":
+	default:
+		return fail("unknown format %q", *format)
+	}
+	if *path == "" {
+		return fail("no dictionary: use -dict or set JMDICT")
+	}
+	queries := flags.Args()
+	if len(queries) == 0 && !*readStdin {
+		flags.Usage()
+		return 2
+	}
+
+	dict, err := jmdict.Open(*path)
+	if err != nil {
+		return fail("%s: %v", *path, err)
+	}
+	l := &lookup{
+		index:     jmdict.NewIndex(dict),
+		mode:      *mode,
+		deinflect: *deinflect,
+		format:    *format,
+		limit:     *limit,
+		out:       bufio.NewWriter(stdout),
+	}
+	defer l.out.Flush()
+
+	status := 0
+	batch := *readStdin || len(queries) > 1
+	for _, q := range queries {
+		if !l.query(q, batch) {
+			status = 1
+		}
+	}
+	if *readStdin {
+		scanner := bufio.NewScanner(stdin)
+		for scanner.Scan() {
+			q := strings.TrimSpace(scanner.Text())
+			if q == "" {
+				continue
+			}
+			if !l.query(q, true) {
+				status = 1
+			}
+			l.out.Flush()
+		}
+		if err := scanner.Err(); err != nil {
+			return fail("reading queries: %v", err)
+		}
+	}
+	return status
+}
+
+func fail(format string, args ...interface{}) int {
+	fmt.Fprintf(stderr, "jmdict: "+format+"\n", args...)
+	return 2
+}
+
+// Returns the exit status for an error from parsing flags, which the
+// flag package has already reported.
+func parseStatus(err error) int {
+	if err == flag.ErrHelp {
+		return 0
+	}
+	return 2
+}
+
+// Looks up q and writes the matches, reporting whether there were any.
+// In batch mode the output for each query is labelled with the query.
+func (l *lookup) query(q string, batch bool) bool {
+	matches := l.find(q)
+	if l.limit > 0 && len(matches) > l.limit {
+		matches = matches[:l.limit]
+	}
+
+	switch l.format {
+	case "json":
+		result := struct {
+			Query   string            `json:"query"`
+			Entries []json.RawMessage `json:"entries"`
+		}{Query: q, Entries: []json.RawMessage{}}
+		for _, m := range matches {
+			entry, _ := json.Marshal(m.Entry)
+			result.Entries = append(result.Entries, annotate(entry, m))
+		}
+		enc := json.NewEncoder(l.out)
+		enc.SetEscapeHTML(false)
+		enc.Encode(result)
+	case "edict":
+		for _, m := range matches {
+			if line := m.Entry.EDICT2(); line != "" {
+				if batch {
+					fmt.Fprintf(l.out, "%s\t", q)
+				}
+				fmt.Fprintln(l.out, line)
+			}
+		}
+	default:
+		if batch {
+			fmt.Fprintf(l.out, "# %s\n", q)
+		}
+		for _, m := range matches {
+			writeText(l.out, m)
+		}
+		if batch && len(matches) == 0 {
+			fmt.Fprintln(l.out, "(no matches)")
+		}
+		if batch {
+			fmt.Fprintln(l.out)
+		}
+	}
+	return len(matches) > 0
+}
+
+// Returns the entries matching q in the lookup's mode.
+func (l *lookup) find(q string) []jmdict.Match {
+	if l.deinflect && (l.mode == "exact" || l.mode == "romaji") {
+		if l.mode == "romaji" {
+			q = jmdict.RomajiToKana(q)
+		}
+		return l.index.Inflected(q)
+	}
+	var entries []*jmdict.Entry
+	switch l.mode {
+	case "exact":
+		entries = l.index.Exact(q)
+	case "prefix":
+		entries = l.index.Prefix(q)
+	case "gloss":
+		entries = l.index.Gloss(q)
+	case "romaji":
+		entries = l.index.Romaji(q)
+	}
+	matches := make([]jmdict.Match, len(entries))
+	for i, e := range entries {
+		matches[i] = jmdict.Match{Entry: e}
+	}
+	return matches
+}
+
+// Adds the deinflection of a match to its JSON encoded entry.
+func annotate(entry []byte, m jmdict.Match) json.RawMessage {
+	if len(m.Reasons) == 0 {
+		return entry
+	}
+	extra, _ := json.Marshal(struct {
+		Word    string   `json:"deinflected"`
+		Reasons []string `json:"inflections"`
+	}{m.Word, m.Reasons})
+	// Splice the fields into the entry object.
+	return json.RawMessage(string(entry[:len(entry)-1]) + "," + string(extra[1:]))
+}
+
+// Writes a match as text, for instance
+//
+//	食べる【たべる】 [1358280]
+//	  also 喰べる【たべる】
+//	  食べる: past
+//	  1. (Ichidan verb; transitive verb) to eat
+//	  2. to live on (e.g. a salary); to live off
+func writeText(w io.Writer, m jmdict.Match) {
+	e := m.Entry
+	head, alternates := e.Headword()
+	fmt.Fprintf(w, "%s [%d]\n", head.String(), e.Id)
+	if len(alternates) > 0 {
+		forms := make([]string, len(alternates))
+		for i, p := range alternates {
+			forms[i] = p.String()
+		}
+		fmt.Fprintf(w, "  also %s\n", strings.Join(forms, "、"))
+	}
+	if len(m.Reasons) > 0 {
+		fmt.Fprintf(w, "  %s: %s\n", m.Word, strings.Join(m.Reasons, ", "))
+	}
+
+	for i := range e.Sense {
+		s := &e.Sense[i]
+		var tags []string
+		if len(s.Position) > 0 {
+			descs := make([]string, len(s.Position))
+			for j, p := range s.Position {
+				descs[j] = describe(string(p), jmdict.DescribePosition(p))
+			}
+			tags = append(tags, "("+strings.Join(descs, "; ")+")")
+		}
+		for _, f := range s.Field {
+			tags = append(tags, "{"+describe(string(f), jmdict.DescribeField(f))+"}")
+		}
+		for _, m := range s.Misc {
+			tags = append(tags, "("+string(m)+")")
+		}
+		for _, d := range s.Dialect {
+			tags = append(tags, "("+describe(string(d), jmdict.DescribeDialect(d))+")")
+		}
+		restrict := append(append([]string(nil), s.KanjiRestrict...), s.ReadingRestrict...)
+		if len(restrict) > 0 {
+			tags = append(tags, "("+strings.Join(restrict, ", ")+" only)")
+		}
+		tags = append(tags, strings.Join(s.Gloss, "; "))
+		for _, info := range s.Info {
+			tags = append(tags, "("+info+")")
+		}
+		if len(s.Xref) > 0 {
+			tags = append(tags, "see "+strings.Join(s.Xref, ", "))
+		}
+		if len(s.Antonym) > 0 {
+			tags = append(tags, "antonym "+strings.Join(s.Antonym, ", "))
+		}
+		fmt.Fprintf(w, "  %d. %s\n", i+1, strings.Join(tags, " "))
+	}
+}
+
+// Returns the description of a tag, or its code if it has none.
+func describe(code, desc string) string {
+	if desc == "" {
+		return code
+	}
+	return desc
+}
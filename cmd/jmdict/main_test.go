@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/0xfaded/jmdict"
+)
+
+// Runs the command with the given standard input, returning its exit
+// status and output.
+func runCommand(t *testing.T, input string, args ...string) (status int, out, errOut string) {
+	t.Helper()
+	var o, e bytes.Buffer
+	oldIn, oldOut, oldErr := stdin, stdout, stderr
+	stdin, stdout, stderr = strings.NewReader(input), &o, &e
+	defer func() { stdin, stdout, stderr = oldIn, oldOut, oldErr }()
+	status = run(args)
+	return status, o.String(), e.String()
+}
+
+// Writes the sample dictionary as a binary snapshot in a temporary
+// directory and returns its path.
+func testDict(t *testing.T) string {
+	t.Helper()
+	dict, err := jmdict.Open("../../testdata/sample.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jmdict.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := jmdict.WriteBinary(f, dict); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunText(t *testing.T) {
+	path := testDict(t)
+	status, out, _ := runCommand(t, "", "-dict", path, "食べさせなかった")
+	want := `食べる【たべる】 [1358280]
+  also 喰べる【たべる】
+  食べる: causative, negative, past
+  1. (Ichidan verb; transitive verb) to eat; essen see 食う・1
+  2. to live on (e.g. a salary); to live off
+`
+	if status != 0 || out != want {
+		t.Errorf("status %d, output:\n%s\nwant:\n%s", status, out, want)
+	}
+
+	// The dictionary may also be given by the environment.
+	t.Setenv("JMDICT", path)
+	status, out, _ = runCommand(t, "", "-mode", "prefix", "-deinflect=false", "-limit", "1", "こ")
+	if want := "これ [1628530]\n"; status != 0 || !strings.HasPrefix(out, want) || strings.Count(out, "[") != 1 {
+		t.Errorf("status %d, output:\n%s\nwant one entry starting %q", status, out, want)
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	status, out, _ := runCommand(t, "", "-dict", testDict(t), "-format", "json", "食べさせなかった", "xyz")
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if status != 1 || len(lines) != 2 {
+		t.Fatalf("status %d, output:\n%s\nwant a line per query", status, out)
+	}
+
+	var result struct {
+		Query   string `json:"query"`
+		Entries []struct {
+			Seq         jmdict.EntSeq `json:"ent_seq"`
+			Deinflected string        `json:"deinflected"`
+			Inflections []string      `json:"inflections"`
+		} `json:"entries"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Query != "食べさせなかった" || len(result.Entries) != 1 || result.Entries[0].Seq != 1358280 ||
+		result.Entries[0].Deinflected != "食べる" ||
+		!reflect.DeepEqual(result.Entries[0].Inflections, []string{"causative", "negative", "past"}) {
+		t.Errorf("result %+v from %s", result, lines[0])
+	}
+	if want := `{"query":"xyz","entries":[]}`; lines[1] != want {
+		t.Errorf("no matches gave %s, want %s", lines[1], want)
+	}
+}
+
+func TestRunEDICT(t *testing.T) {
+	path := testDict(t)
+	status, out, _ := runCommand(t, "", "-dict", path, "-format", "edict", "-mode", "romaji", "ikanai")
+	if status != 0 || !strings.HasPrefix(out, "行く(P) [いく(P);ゆく(P)] /") || !strings.HasSuffix(out, "/EntL1578850/\n") {
+		t.Errorf("status %d, output %q, want the line of 行く", status, out)
+	}
+
+	// In batch mode each line is labelled with its query.
+	status, out, _ = runCommand(t, "", "-dict", path, "-format", "edict", "-mode", "gloss", "eat", "xyz")
+	if status != 1 || !strings.HasPrefix(out, "eat\t食べる(P);喰べる(iK) [たべる(P)] /") || strings.Count(out, "\n") != 1 {
+		t.Errorf("status %d, output %q", status, out)
+	}
+}
+
+func TestRunStdin(t *testing.T) {
+	status, out, _ := runCommand(t, "子供\n\n  xyz \n", "-dict", testDict(t), "-stdin", "-limit", "0", "これ")
+	for _, want := range []string{"# これ\nこれ [1628530]", "# 子供\nこども [1591900]", "# xyz\n(no matches)\n\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output:\n%s\nwant %q", out, want)
+		}
+	}
+	if status != 1 || strings.Count(out, "# ") != 3 {
+		t.Errorf("status %d with %d queries, want 1 and 3", status, strings.Count(out, "# "))
+	}
+
+	status, out, _ = runCommand(t, "これ\n", "-dict", testDict(t), "-stdin")
+	if status != 0 || !strings.HasPrefix(out, "# これ\n") {
+		t.Errorf("status %d, output:\n%s", status, out)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Setenv("JMDICT", "")
+	path := testDict(t)
+	tests := []struct {
+		args   []string
+		status int
+		err    string
+	}{
+		{[]string{"-dict", path, "xyz"}, 1, ""},
+		{[]string{"-dict", path, "-mode", "fuzzy", "x"}, 2, `jmdict: unknown mode "fuzzy"`},
+		{[]string{"-dict", path, "-format", "xml", "x"}, 2, `jmdict: unknown format "xml"`},
+		{[]string{"x"}, 2, "jmdict: no dictionary: use -dict or set JMDICT"},
+		{[]string{"-dict", path}, 2, "Usage of jmdict:"},
+		{[]string{"-dict", filepath.Join(t.TempDir(), "missing"), "x"}, 2, "jmdict: "},
+		{[]string{"-limit", "ten", "x"}, 2, `invalid value "ten" for flag -limit`},
+		{[]string{"-h"}, 0, "Usage of jmdict:"},
+	}
+	for _, test := range tests {
+		status, _, errOut := runCommand(t, "", test.args...)
+		if status != test.status || !strings.Contains(errOut, test.err) {
+			t.Errorf("%q: status %d, error output %q, want %d and %q", test.args, status, errOut, test.status, test.err)
+		}
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	entry := []byte(`{"ent_seq":1}`)
+	if got := annotate(entry, jmdict.Match{}); string(got) != string(entry) {
+		t.Errorf("annotate without inflections = %s", got)
+	}
+	got := annotate(entry, jmdict.Match{Deinflection: jmdict.Deinflection{Word: "食べる", Reasons: []string{"past"}}})
+	if want := `{"ent_seq":1,"deinflected":"食べる","inflections":["past"]}`; string(got) != want {
+		t.Errorf("annotate = %s, want %s", got, want)
+	}
+	if !json.Valid(got) {
+		t.Errorf("annotate gave invalid JSON %s", got)
+	}
+}
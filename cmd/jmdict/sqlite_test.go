@@ -0,0 +1,33 @@
+//go:build sqlite
+
+package main
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+func TestConvertSQLite(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "jmdict.db")
+	// Converting again replaces the database.
+	for _, test := range []struct {
+		filter string
+		count  int
+	}{{"", 8}, {"pri:nf<=2", 3}} {
+		status, _, errOut := runCommand(t, "", "convert", "-to", "sqlite", "-o", out, "-filter", test.filter, samplePath)
+		if status != 0 {
+			t.Fatalf("filter %q: status %d: %s", test.filter, status, errOut)
+		}
+		db, err := sql.Open(sqliteDriver, out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var n int
+		err = db.QueryRow(`SELECT count(*) FROM entry`).Scan(&n)
+		db.Close()
+		if err != nil || n != test.count {
+			t.Errorf("filter %q: %d entries, error %v, want %d", test.filter, n, err, test.count)
+		}
+	}
+}
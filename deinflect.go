@@ -0,0 +1,202 @@
+package jmdict
+
+import "strings"
+
+// Word classes used to chain deinflection rules. A form class describes
+// what an inflected word may be, and so which rules may undo it.
+const (
+	formV1    = 1 << iota // ichidan verb
+	formV5                // godan verb
+	formVK                // kuru verb
+	formVS                // suru verb
+	formAdjI              // i-adjective, including ない and たい forms
+	formFinal             // a form which does not inflect further
+
+	formAny = formV1 | formV5 | formVK | formVS | formAdjI | formFinal
+)
+
+// A deinflectRule turns a word ending in from, of one of the classes in,
+// into a word ending in to of class out.
+type deinflectRule struct {
+	from, to string
+	in, out  int
+	reason   string
+}
+
+// The rows of the godan conjugation: the dictionary form ending, and the
+// a, i, e and o row kana and the te and ta forms.
+var godanRows = [][7]string{
+	{"う", "わ", "い", "え", "お", "って", "った"},
+	{"く", "か", "き", "け", "こ", "いて", "いた"},
+	{"ぐ", "が", "ぎ", "げ", "ご", "いで", "いだ"},
+	{"す", "さ", "し", "せ", "そ", "して", "した"},
+	{"つ", "た", "ち", "て", "と", "って", "った"},
+	{"ぬ", "な", "に", "ね", "の", "んで", "んだ"},
+	{"ぶ", "ば", "び", "べ", "ぼ", "んで", "んだ"},
+	{"む", "ま", "み", "め", "も", "んで", "んだ"},
+	{"る", "ら", "り", "れ", "ろ", "って", "った"},
+}
+
+var deinflectRules = buildDeinflectRules()
+
+func buildDeinflectRules() []deinflectRule {
+	var rules []deinflectRule
+	add := func(from, to string, in, out int, reason string) {
+		rules = append(rules, deinflectRule{from, to, in, out, reason})
+	}
+
+	// Endings shared by every verb class, following the stem or the
+	// te and ta forms.
+	verb := func(stem, neg, te, ta, base string, out int) {
+		add(neg+"ない", base, formAdjI, out, "negative")
+		add(ta, base, formFinal, out, "past")
+		add(te, base, formFinal, out, "te form")
+		add(ta+"ら", base, formFinal, out, "conditional")
+		add(ta+"り", base, formFinal, out, "-tari")
+		add(te+"いる", base, formV1, out, "progressive")
+		add(te+"る", base, formV1, out, "progressive")
+		add(stem+"ます", base, formFinal, out, "polite")
+		add(stem+"ました", base, formFinal, out, "polite past")
+		add(stem+"ません", base, formFinal, out, "polite negative")
+		add(stem+"ませんでした", base, formFinal, out, "polite past negative")
+		add(stem+"ましょう", base, formFinal, out, "polite volitional")
+		add(stem+"たい", base, formAdjI, out, "-tai")
+		add(stem+"なさい", base, formFinal, out, "polite imperative")
+	}
+
+	for _, row := range godanRows {
+		base, a, i, e, o, te, ta := row[0], row[1], row[2], row[3], row[4], row[5], row[6]
+		verb(i, a, te, ta, base, formV5)
+		add(a+"れる", base, formV1, formV5, "passive")
+		add(a+"せる", base, formV1, formV5, "causative")
+		add(e+"る", base, formV1, formV5, "potential")
+		add(e+"ば", base, formFinal, formV5, "provisional")
+		add(e, base, formFinal, formV5, "imperative")
+		add(o+"う", base, formFinal, formV5, "volitional")
+	}
+	// 行く has the te and ta forms 行って and 行った.
+	add("って", "く", formFinal, formV5, "te form")
+	add("った", "く", formFinal, formV5, "past")
+
+	verb("", "", "て", "た", "る", formV1)
+	add("られる", "る", formV1, formV1, "passive or potential")
+	add("させる", "る", formV1, formV1, "causative")
+	add("れる", "る", formV1, formV1, "potential")
+	add("れば", "る", formFinal, formV1|formVK, "provisional")
+	add("ろ", "る", formFinal, formV1, "imperative")
+	add("よう", "る", formFinal, formV1, "volitional")
+
+	verb("し", "し", "して", "した", "する", formVS)
+	add("される", "する", formV1, formVS, "passive")
+	add("させる", "する", formV1, formVS, "causative")
+	add("できる", "する", formV1, formVS, "potential")
+	add("すれば", "する", formFinal, formVS, "provisional")
+	add("しろ", "する", formFinal, formVS, "imperative")
+	add("しよう", "する", formFinal, formVS, "volitional")
+	// A noun which takes suru is listed without it.
+	add("する", "", formVS, formVS, "")
+
+	for _, ku := range []string{"く", "来"} {
+		ki, ko := "き", "こ"
+		if ku == "来" {
+			ki, ko = "来", "来"
+		}
+		verb(ki, ko, ki+"て", ki+"た", ku+"る", formVK)
+		add(ko+"られる", ku+"る", formV1, formVK, "passive or potential")
+		add(ko+"れる", ku+"る", formV1, formVK, "potential")
+		add(ko+"させる", ku+"る", formV1, formVK, "causative")
+		add(ko+"い", ku+"る", formFinal, formVK, "imperative")
+		add(ko+"よう", ku+"る", formFinal, formVK, "volitional")
+	}
+
+	add("くない", "い", formAdjI, formAdjI, "negative")
+	add("かった", "い", formFinal, formAdjI, "past")
+	add("くて", "い", formFinal, formAdjI, "te form")
+	add("く", "い", formFinal, formAdjI, "adverbial")
+	add("ければ", "い", formFinal, formAdjI, "provisional")
+	add("かったら", "い", formFinal, formAdjI, "conditional")
+	add("さ", "い", formFinal, formAdjI, "noun")
+	add("そう", "い", formFinal, formAdjI, "-sou")
+	return rules
+}
+
+// A Deinflection is a possible dictionary form of an inflected word.
+type Deinflection struct {
+	Word string
+
+	// The inflections undone, from the innermost outwards, for instance
+	// "causative", "negative", "past" for 食べさせなかった.
+	Reasons []string
+
+	form int
+}
+
+// Deinflect returns the possible dictionary forms of word, starting
+// with word itself. It recognizes the common inflections of verbs and
+// i-adjectives, applied in any sensible order. A form may not be a word
+// at all; use Deinflection.Matches to check a candidate entry.
+func Deinflect(word string) []Deinflection {
+	results := []Deinflection{{Word: word, form: formAny}}
+	seen := map[string]int{word: 0} // index of each form in results
+	for i := 0; i < len(results); i++ {
+		d := results[i]
+		for _, rule := range deinflectRules {
+			if d.form&rule.in == 0 || !strings.HasSuffix(d.Word, rule.from) {
+				continue
+			}
+			base := d.Word[:len(d.Word)-len(rule.from)] + rule.to
+			if base == "" {
+				continue
+			}
+			reasons := d.Reasons
+			if rule.reason != "" {
+				reasons = append([]string{rule.reason}, d.Reasons...)
+			}
+			if j, ok := seen[base]; ok {
+				// Forms are found in order of the number of rules
+				// applied, so the first explanation is the shortest.
+				// Later ones may add classes, as for 来る.
+				results[j].form |= rule.out
+				continue
+			}
+			seen[base] = len(results)
+			results = append(results, Deinflection{base, reasons, rule.out})
+		}
+	}
+	return results
+}
+
+// Matches reports whether the entry has a part-of-speech consistent
+// with the deinflection. Any entry matches the uninflected word.
+func (d Deinflection) Matches(e *Entry) bool {
+	if d.form&formFinal != 0 {
+		return true
+	}
+	for i := range e.Sense {
+		for _, p := range e.Positions(i) {
+			if d.form&positionForm(p) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns the word class of a part-of-speech, or zero if it does not
+// inflect.
+func positionForm(p Position) int {
+	s := string(p)
+	switch {
+	case s == "v1" || s == "v1-s":
+		return formV1
+	case strings.HasPrefix(s, "v5"):
+		return formV5
+	case s == "vk":
+		return formVK
+	case strings.HasPrefix(s, "vs"):
+		return formVS
+	case s == "adj-i" || s == "adj-ix":
+		return formAdjI
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+package jmdict
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeinflect(t *testing.T) {
+	dict := readSample(t)
+	tests := []struct {
+		word    string
+		base    string
+		reasons []string
+		seq     EntSeq
+	}{
+		{"食べる", "食べる", nil, 1358280},
+		{"食べさせなかった", "食べる", []string{"causative", "negative", "past"}, 1358280},
+		{"食べられない", "食べる", []string{"passive", "negative"}, 1358280},
+		{"行った", "行く", []string{"past"}, 1578850},
+		{"高かった", "高い", []string{"past"}, 1280640},
+		{"勉強した", "勉強", []string{"past"}, 1512360},
+	}
+	for _, test := range tests {
+		var found *Deinflection
+		forms := Deinflect(test.word)
+		for i := range forms {
+			if forms[i].Word == test.base {
+				found = &forms[i]
+				break
+			}
+		}
+		if found == nil {
+			t.Errorf("Deinflect(%s) has no %s", test.word, test.base)
+			continue
+		}
+		if len(found.Reasons) != 0 || len(test.reasons) != 0 {
+			if !reflect.DeepEqual(found.Reasons, test.reasons) {
+				t.Errorf("Deinflect(%s): %s by %q, want %q", test.word, test.base, found.Reasons, test.reasons)
+			}
+		}
+		if !found.Matches(sampleEntry(t, dict, test.seq)) {
+			t.Errorf("Deinflect(%s): %s does not match entry %d", test.word, test.base, test.seq)
+		}
+	}
+}
+
+func TestDeinflectionMatches(t *testing.T) {
+	dict := readSample(t)
+	// 高かった is the past of the adjective 高い, not of the noun 勉強.
+	for _, d := range Deinflect("高かった") {
+		if d.Word == "高い" && d.Matches(sampleEntry(t, dict, 1512360)) {
+			t.Errorf("%s %q matches the noun 勉強", d.Word, d.Reasons)
+		}
+	}
+	if d := Deinflect("勉強")[0]; !d.Matches(sampleEntry(t, dict, 1000220)) {
+		t.Error("the uninflected word does not match every entry")
+	}
+}
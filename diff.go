@@ -0,0 +1,452 @@
+package jmdict
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// A Change records a single field level difference within an entry.
+// Path names the field, for instance "k_ele", "r_ele[こども].re_pri" or
+// "sense[2].gloss", using the element names of the DTD. Old and New hold
+// the value removed and added; one of them is empty unless Kind is
+// ChangeModified.
+type Change struct {
+	Path string     `json:"path"`
+	Kind ChangeKind `json:"kind"`
+	Old  string     `json:"old,omitempty"`
+	New  string     `json:"new,omitempty"`
+}
+
+// EntryDiff describes how an entry differs between two releases.
+type EntryDiff struct {
+	Id      EntSeq
+	Old     Entry
+	New     Entry
+	Changes []Change
+}
+
+// Diff describes the differences between two releases of the dictionary.
+// Entries are matched by EntSeq, and each list is ordered by EntSeq.
+type Diff struct {
+	Added    []Entry
+	Removed  []Entry
+	Modified []EntryDiff
+}
+
+// Compare returns the differences between old and new. An entry is
+// modified when CompareEntries reports a change, so XML names and the
+// Code and Rank of priorities, which depend on how a dictionary was
+// loaded, are not differences.
+func Compare(old, new JMDict) Diff {
+	var diff Diff
+	oldById := make(map[EntSeq]*Entry, len(old.Entries))
+	for i := range old.Entries {
+		oldById[old.Entries[i].Id] = &old.Entries[i]
+	}
+	newById := make(map[EntSeq]*Entry, len(new.Entries))
+	for i := range new.Entries {
+		n := &new.Entries[i]
+		newById[n.Id] = n
+		o, ok := oldById[n.Id]
+		if !ok {
+			diff.Added = append(diff.Added, *n)
+		} else if changes := CompareEntries(o, n); len(changes) > 0 {
+			diff.Modified = append(diff.Modified, EntryDiff{
+				Id: n.Id, Old: *o, New: *n, Changes: changes})
+		}
+	}
+	for i := range old.Entries {
+		if _, ok := newById[old.Entries[i].Id]; !ok {
+			diff.Removed = append(diff.Removed, old.Entries[i])
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Id < diff.Added[j].Id })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Id < diff.Removed[j].Id })
+	sort.Slice(diff.Modified, func(i, j int) bool { return diff.Modified[i].Id < diff.Modified[j].Id })
+	return diff
+}
+
+// Empty reports whether the diff has no changes.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// CompareEntries returns the field level changes from old to new.
+// Kanji and reading elements are matched by their phrase and senses by
+// their position. A change in the order of elements or values is
+// reported as a modification of the list. Lists of values are compared
+// as multisets, so a value repeated once more is reported as added.
+func CompareEntries(old, new *Entry) []Change {
+	var changes []Change
+	changes = diffOrder(changes, "k_ele", kebs(old.Kanji), kebs(new.Kanji))
+	changes = diffOrder(changes, "r_ele", rebs(old.Reading), rebs(new.Reading))
+
+	oldKebs, newKebs := kebs(old.Kanji), kebs(new.Kanji)
+	match := matchPhrases(oldKebs, newKebs)
+	for i := range new.Kanji {
+		o, n := match[i], &new.Kanji[i]
+		if o < 0 {
+			changes = append(changes, Change{Path: "k_ele", Kind: ChangeAdded, New: string(n.Phrase)})
+			continue
+		}
+		path := "k_ele" + phraseIndex(newKebs, i)
+		changes = diffStrings(changes, path+".ke_inf", stringSlice(old.Kanji[o].Info), stringSlice(n.Info))
+		changes = diffStrings(changes, path+".ke_pri", kePriorities(old.Kanji[o].Priority), kePriorities(n.Priority))
+	}
+	for _, i := range unmatched(match, len(old.Kanji)) {
+		changes = append(changes, Change{Path: "k_ele", Kind: ChangeRemoved, Old: oldKebs[i]})
+	}
+
+	oldRebs, newRebs := rebs(old.Reading), rebs(new.Reading)
+	match = matchPhrases(oldRebs, newRebs)
+	for i := range new.Reading {
+		n := &new.Reading[i]
+		if match[i] < 0 {
+			changes = append(changes, Change{Path: "r_ele", Kind: ChangeAdded, New: string(n.Phrase)})
+			continue
+		}
+		o := &old.Reading[match[i]]
+		path := "r_ele" + phraseIndex(newRebs, i)
+		if (o.ImproperReading == nil) != (n.ImproperReading == nil) {
+			changes = append(changes, Change{Path: path + ".re_nokanji", Kind: ChangeModified,
+				Old: fmt.Sprint(o.ImproperReading != nil), New: fmt.Sprint(n.ImproperReading != nil)})
+		}
+		changes = diffStrings(changes, path+".re_restr", stringSlice(o.Restrict), stringSlice(n.Restrict))
+		changes = diffStrings(changes, path+".re_inf", stringSlice(o.Orthography), stringSlice(n.Orthography))
+		changes = diffStrings(changes, path+".re_pri", rePriorities(o.Priority), rePriorities(n.Priority))
+	}
+	for _, i := range unmatched(match, len(old.Reading)) {
+		changes = append(changes, Change{Path: "r_ele", Kind: ChangeRemoved, Old: oldRebs[i]})
+	}
+
+	for i := 0; i < len(old.Sense) || i < len(new.Sense); i++ {
+		path := fmt.Sprintf("sense[%d]", i+1)
+		if i >= len(old.Sense) {
+			changes = append(changes, Change{Path: path, Kind: ChangeAdded,
+				New: strings.Join(new.Sense[i].Gloss, "; ")})
+			continue
+		}
+		if i >= len(new.Sense) {
+			changes = append(changes, Change{Path: path, Kind: ChangeRemoved,
+				Old: strings.Join(old.Sense[i].Gloss, "; ")})
+			continue
+		}
+		o, n := &old.Sense[i], &new.Sense[i]
+		changes = diffStrings(changes, path+".stagk", o.KanjiRestrict, n.KanjiRestrict)
+		changes = diffStrings(changes, path+".stagr", o.ReadingRestrict, n.ReadingRestrict)
+		changes = diffStrings(changes, path+".pos", stringSlice(o.Position), stringSlice(n.Position))
+		changes = diffStrings(changes, path+".xref", o.Xref, n.Xref)
+		changes = diffStrings(changes, path+".ant", o.Antonym, n.Antonym)
+		changes = diffStrings(changes, path+".field", stringSlice(o.Field), stringSlice(n.Field))
+		changes = diffStrings(changes, path+".misc", stringSlice(o.Misc), stringSlice(n.Misc))
+		changes = diffStrings(changes, path+".s_inf", o.Info, n.Info)
+		changes = diffStrings(changes, path+".dial", stringSlice(o.Dialect), stringSlice(n.Dialect))
+		changes = diffStrings(changes, path+".example", o.Example, n.Example)
+		changes = diffStrings(changes, path+".lsource", lsources(o.LSource), lsources(n.LSource))
+		changes = diffGlosses(changes, path+".gloss", o, n)
+	}
+
+	oi, ni := &old.Info, &new.Info
+	changes = diffStrings(changes, "info.links", links(oi.Links), links(ni.Links))
+	changes = diffStrings(changes, "info.bibl", bibls(oi.Bibl), bibls(ni.Bibl))
+	changes = diffStrings(changes, "info.etym", oi.Etym, ni.Etym)
+	changes = diffStrings(changes, "info.audit", audits(oi.Audit), audits(ni.Audit))
+	return changes
+}
+
+// Records the values added to and removed from a list, counting
+// repeated values. When none were, a change in their order is recorded
+// as a modification of the whole list.
+func diffStrings(changes []Change, path string, old, new []string) []Change {
+	n := len(changes)
+	for i, s := range new {
+		if countString(new[:i+1], s) > countString(old, s) {
+			changes = append(changes, Change{Path: path, Kind: ChangeAdded, New: s})
+		}
+	}
+	for i, s := range old {
+		if countString(old[:i+1], s) > countString(new, s) {
+			changes = append(changes, Change{Path: path, Kind: ChangeRemoved, Old: s})
+		}
+	}
+	if len(changes) == n && !reflect.DeepEqual(old, new) && len(old)+len(new) > 0 {
+		changes = append(changes, Change{Path: path, Kind: ChangeModified,
+			Old: strings.Join(old, "; "), New: strings.Join(new, "; ")})
+	}
+	return changes
+}
+
+// Matches each element of new to the element of old with the same
+// phrase, the second element with a phrase matching the second with it,
+// and so on. Unmatched elements are -1.
+func matchPhrases(old, new []string) []int {
+	match := make([]int, len(new))
+	for i, s := range new {
+		match[i] = -1
+		for j, seen := 0, countString(new[:i], s); j < len(old); j++ {
+			if old[j] == s {
+				if seen == 0 {
+					match[i] = j
+					break
+				}
+				seen--
+			}
+		}
+	}
+	return match
+}
+
+// Returns the elements of old left unmatched by matchPhrases.
+func unmatched(match []int, old int) []int {
+	used := make([]bool, old)
+	for _, j := range match {
+		if j >= 0 {
+			used[j] = true
+		}
+	}
+	var list []int
+	for j, ok := range used {
+		if !ok {
+			list = append(list, j)
+		}
+	}
+	return list
+}
+
+// Names the i'th element in a path: "[phrase]", or "[phrase#2]" for the
+// second element with a repeated phrase.
+func phraseIndex(phrases []string, i int) string {
+	if k := countString(phrases[:i], phrases[i]); k > 0 {
+		return fmt.Sprintf("[%s#%d]", phrases[i], k+1)
+	}
+	return "[" + phrases[i] + "]"
+}
+
+// Records a change in the order of the elements common to old and new.
+func diffOrder(changes []Change, path string, old, new []string) []Change {
+	var o []int
+	for _, j := range matchPhrases(old, new) {
+		if j >= 0 {
+			o = append(o, j)
+		}
+	}
+	sorted := true
+	for i := 1; i < len(o); i++ {
+		sorted = sorted && o[i-1] < o[i]
+	}
+	if !sorted {
+		changes = append(changes, Change{Path: path, Kind: ChangeModified,
+			Old: strings.Join(old, "; "), New: strings.Join(new, "; ")})
+	}
+	return changes
+}
+
+func countString(list []string, s string) int {
+	n := 0
+	for _, x := range list {
+		if x == s {
+			n++
+		}
+	}
+	return n
+}
+
+// Like diffStrings, but when a gloss has been replaced in place it is
+// reported as an edit rather than as an addition and a removal, and a
+// change in the language of a gloss is reported on its own.
+func diffGlosses(changes []Change, path string, o, n *Sense) []Change {
+	old, new := o.Gloss, n.Gloss
+	if len(old) != len(new) {
+		return diffStrings(changes, path, old, new)
+	}
+	edited := len(changes)
+	for i := range old {
+		if old[i] != new[i] && !containsString(new, old[i]) && !containsString(old, new[i]) {
+			changes = append(changes, Change{Path: fmt.Sprintf("%s[%d]", path, i+1),
+				Kind: ChangeModified, Old: old[i], New: new[i]})
+		}
+	}
+	if len(changes) == edited {
+		changes = diffStrings(changes, path, old, new)
+	}
+	for i := range old {
+		if old[i] == new[i] && o.GlossLanguage(i) != n.GlossLanguage(i) {
+			changes = append(changes, Change{Path: fmt.Sprintf("%s[%d].lang", path, i+1),
+				Kind: ChangeModified, Old: o.GlossLanguage(i), New: n.GlossLanguage(i)})
+		}
+	}
+	return changes
+}
+
+func kebs(kanji []KEle) []string {
+	phrases := make([]string, len(kanji))
+	for i, k := range kanji {
+		phrases[i] = string(k.Phrase)
+	}
+	return phrases
+}
+
+func rebs(readings []REle) []string {
+	phrases := make([]string, len(readings))
+	for i, r := range readings {
+		phrases[i] = string(r.Phrase)
+	}
+	return phrases
+}
+
+// The following describe elements in reports, leaving out XML names.
+
+func lsources(list []LSource) []string {
+	strs := make([]string, len(list))
+	for i, l := range list {
+		strs[i] = fmt.Sprintf("%s:%s", l.Lang, l.Source)
+		if l.Type != "" {
+			strs[i] += " ls_type=" + l.Type
+		}
+		if l.Wasei != "" {
+			strs[i] += " ls_wasei=" + l.Wasei
+		}
+	}
+	return strs
+}
+
+func links(list []Links) []string {
+	strs := make([]string, len(list))
+	for i, l := range list {
+		strs[i] = fmt.Sprintf("%s: %s <%s>", l.LinkTag, l.LinkDesc, l.LinkUri)
+	}
+	return strs
+}
+
+func bibls(list []Bibl) []string {
+	strs := make([]string, len(list))
+	for i, b := range list {
+		strs[i] = fmt.Sprintf("%s: %s", b.BibTag, b.BibTxt)
+	}
+	return strs
+}
+
+func audits(list []Audit) []string {
+	strs := make([]string, len(list))
+	for i, a := range list {
+		strs[i] = a.UpdDate + " " + a.UpdDetl
+	}
+	return strs
+}
+
+// Converts a slice of a string type, such as []Misc, to []string.
+func stringSlice(list interface{}) []string {
+	v := reflect.ValueOf(list)
+	strs := make([]string, v.Len())
+	for i := range strs {
+		strs[i] = v.Index(i).String()
+	}
+	return strs
+}
+
+func kePriorities(pris []KePriority) []string {
+	raw := make([]string, len(pris))
+	for i, p := range pris {
+		raw[i] = p.Raw
+	}
+	return raw
+}
+
+func rePriorities(pris []RePriority) []string {
+	raw := make([]string, len(pris))
+	for i, p := range pris {
+		raw[i] = p.Raw
+	}
+	return raw
+}
+
+// Short label for an entry in reports: its headword and reading.
+func entryLabel(e *Entry) string {
+	p, _ := e.Headword()
+	return p.String()
+}
+
+// WriteText writes a human readable report of the diff to w, suitable
+// for release notes.
+func (d Diff) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d added, %d removed, %d modified\n",
+		len(d.Added), len(d.Removed), len(d.Modified))
+	for i := range d.Added {
+		fmt.Fprintf(&b, "+ %d %s\n", d.Added[i].Id, entryLabel(&d.Added[i]))
+	}
+	for i := range d.Removed {
+		fmt.Fprintf(&b, "- %d %s\n", d.Removed[i].Id, entryLabel(&d.Removed[i]))
+	}
+	for i := range d.Modified {
+		m := &d.Modified[i]
+		fmt.Fprintf(&b, "~ %d %s\n", m.Id, entryLabel(&m.New))
+		for _, c := range m.Changes {
+			switch c.Kind {
+			case ChangeAdded:
+				fmt.Fprintf(&b, "    %s: added %q\n", c.Path, c.New)
+			case ChangeRemoved:
+				fmt.Fprintf(&b, "    %s: removed %q\n", c.Path, c.Old)
+			default:
+				if c.Old == "" && c.New == "" {
+					fmt.Fprintf(&b, "    %s: modified\n", c.Path)
+				} else {
+					fmt.Fprintf(&b, "    %s: %q -> %q\n", c.Path, c.Old, c.New)
+				}
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type diffReportEntry struct {
+	Id       EntSeq   `json:"ent_seq"`
+	Headword string   `json:"headword"`
+	Changes  []Change `json:"changes,omitempty"`
+}
+
+type diffReport struct {
+	Added    []diffReportEntry `json:"added"`
+	Removed  []diffReportEntry `json:"removed"`
+	Modified []diffReportEntry `json:"modified"`
+}
+
+// WriteJSON writes a JSON report of the diff to w. Entries are
+// identified by ent_seq and headword rather than written in full.
+func (d Diff) WriteJSON(w io.Writer) error {
+	report := diffReport{
+		Added:    []diffReportEntry{},
+		Removed:  []diffReportEntry{},
+		Modified: []diffReportEntry{},
+	}
+	for i := range d.Added {
+		report.Added = append(report.Added,
+			diffReportEntry{Id: d.Added[i].Id, Headword: entryLabel(&d.Added[i])})
+	}
+	for i := range d.Removed {
+		report.Removed = append(report.Removed,
+			diffReportEntry{Id: d.Removed[i].Id, Headword: entryLabel(&d.Removed[i])})
+	}
+	for i := range d.Modified {
+		m := &d.Modified[i]
+		report.Modified = append(report.Modified,
+			diffReportEntry{Id: m.Id, Headword: entryLabel(&m.New), Changes: m.Changes})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
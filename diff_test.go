@@ -0,0 +1,167 @@
+package jmdict
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+// Returns the sample and a later release of it with one entry added,
+// one removed and one modified.
+func sampleRelease(t *testing.T) (old, new JMDict) {
+	old, new = readSample(t), readSample(t)
+	for i := range new.Entries {
+		if new.Entries[i].Id == 1280640 {
+			new.Entries = append(new.Entries[:i], new.Entries[i+1:]...)
+			break
+		}
+	}
+	new.Entries = append(new.Entries, Entry{
+		Id:      2000010,
+		Reading: []REle{{Phrase: "てすと"}},
+		Sense:   []Sense{{Gloss: []string{"test"}, GlossLang: []string{"eng"}}},
+	})
+	e := sampleEntry(t, new, 1358280)
+	e.Kanji = e.Kanji[:1]
+	e.Sense[0].Misc = append(e.Sense[0].Misc, "col")
+	e.Sense[0].Gloss[0] = "to devour"
+	return old, new
+}
+
+func entrySeqs(entries []Entry) []EntSeq {
+	seqs := make([]EntSeq, len(entries))
+	for i := range entries {
+		seqs[i] = entries[i].Id
+	}
+	return seqs
+}
+
+func TestCompare(t *testing.T) {
+	old, new := sampleRelease(t)
+	diff := Compare(old, new)
+	if got := entrySeqs(diff.Added); !reflect.DeepEqual(got, []EntSeq{2000010}) {
+		t.Errorf("added %v, want [2000010]", got)
+	}
+	if got := entrySeqs(diff.Removed); !reflect.DeepEqual(got, []EntSeq{1280640}) {
+		t.Errorf("removed %v, want [1280640]", got)
+	}
+	if len(diff.Modified) != 1 || diff.Modified[0].Id != 1358280 {
+		t.Fatalf("modified %+v, want 1358280 only", diff.Modified)
+	}
+	want := []Change{
+		{Path: "k_ele", Kind: ChangeRemoved, Old: "喰べる"},
+		{Path: "sense[1].misc", Kind: ChangeAdded, New: "col"},
+		{Path: "sense[1].gloss[1]", Kind: ChangeModified, Old: "to eat", New: "to devour"},
+	}
+	if got := diff.Modified[0].Changes; !reflect.DeepEqual(got, want) {
+		t.Errorf("changes %+v, want %+v", got, want)
+	}
+
+	if !Compare(old, old).Empty() {
+		t.Error("dictionary differs from itself")
+	}
+}
+
+func TestCompareEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  EntSeq
+		edit func(e *Entry)
+		want []Change
+	}{
+		{"xml names", 1358280, func(e *Entry) {
+			e.XMLName = xml.Name{}
+			e.Kanji[0].XMLName.Local = "keb"
+			e.Info.XMLName = xml.Name{}
+		}, nil},
+		{"priority codes", 1358280, func(e *Entry) {
+			e.Kanji[0].Priority[0].Priority.Code = "x"
+			e.Kanji[0].Priority[0].Priority.Rank = 7
+		}, nil},
+		{"default gloss language", 1358280, func(e *Entry) {
+			e.Sense[0].GlossLang[0] = ""
+		}, nil},
+		{"gloss language", 1358280, func(e *Entry) {
+			e.Sense[0].GlossLang[1] = "fre"
+		}, []Change{{Path: "sense[1].gloss[2].lang", Kind: ChangeModified, Old: "ger", New: "fre"}}},
+		{"gloss order", 1358280, func(e *Entry) {
+			g := e.Sense[1].Gloss
+			g[0], g[1] = g[1], g[0]
+		}, []Change{{Path: "sense[2].gloss", Kind: ChangeModified,
+			Old: "to live on (e.g. a salary); to live off", New: "to live off; to live on (e.g. a salary)"}}},
+		{"kanji order", 1358280, func(e *Entry) {
+			e.Kanji[0], e.Kanji[1] = e.Kanji[1], e.Kanji[0]
+		}, []Change{{Path: "k_ele", Kind: ChangeModified, Old: "食べる; 喰べる", New: "喰べる; 食べる"}}},
+		{"position order", 1358280, func(e *Entry) {
+			e.Sense[0].Position = []Position{"vt", "v1"}
+		}, []Change{{Path: "sense[1].pos", Kind: ChangeModified, Old: "v1; vt", New: "vt; v1"}}},
+		{"duplicate value", 1358280, func(e *Entry) {
+			e.Sense[0].Xref = append(e.Sense[0].Xref, e.Sense[0].Xref[0])
+		}, []Change{{Path: "sense[1].xref", Kind: ChangeAdded, New: "食う・1"}}},
+		{"repeated reading", 1080180, func(e *Entry) {
+			e.Reading[1].ImproperReading = nil
+		}, []Change{{Path: "r_ele[ロンドン#2].re_nokanji", Kind: ChangeModified, Old: "true", New: "false"}}},
+		{"audit", 1358280, func(e *Entry) {
+			e.Info.Audit[0].UpdDate = "2011-01-01"
+		}, []Change{
+			{Path: "info.audit", Kind: ChangeAdded, New: "2011-01-01 Entry amended"},
+			{Path: "info.audit", Kind: ChangeRemoved, Old: "2010-08-02 Entry amended"},
+		}},
+		{"lsource", 1080180, func(e *Entry) {
+			e.Sense[0].LSource[0].Wasei = "y"
+		}, []Change{
+			{Path: "sense[1].lsource", Kind: ChangeAdded, New: ":London ls_wasei=y"},
+			{Path: "sense[1].lsource", Kind: ChangeRemoved, Old: ":London"},
+		}},
+	}
+	for _, test := range tests {
+		old, new := readSample(t), readSample(t)
+		test.edit(sampleEntry(t, new, test.seq))
+		got := CompareEntries(sampleEntry(t, old, test.seq), sampleEntry(t, new, test.seq))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: changes %+v, want %+v", test.name, got, test.want)
+		}
+		if modified := len(Compare(old, new).Modified) > 0; modified != (test.want != nil) {
+			t.Errorf("%s: entry modified %v", test.name, modified)
+		}
+		if same := ContentHash(old) == ContentHash(new); same != (got == nil) {
+			t.Errorf("%s: content hashes equal %v with changes %v", test.name, same, got)
+		}
+	}
+}
+
+func TestDiffReports(t *testing.T) {
+	diff := Compare(sampleRelease(t))
+
+	var text bytes.Buffer
+	if err := diff.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	want := `1 added, 1 removed, 1 modified
++ 2000010 てすと
+- 1280640 高い【たかい】
+~ 1358280 食べる【たべる】
+    k_ele: removed "喰べる"
+    sense[1].misc: added "col"
+    sense[1].gloss[1]: "to eat" -> "to devour"
+`
+	if text.String() != want {
+		t.Errorf("WriteText:\n%s\nwant:\n%s", text.String(), want)
+	}
+
+	var buf bytes.Buffer
+	if err := diff.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var report diffReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Added) != 1 || report.Added[0].Headword != "てすと" ||
+		len(report.Removed) != 1 || report.Removed[0].Id != 1280640 ||
+		len(report.Modified) != 1 || len(report.Modified[0].Changes) != 3 {
+		t.Errorf("WriteJSON = %s", buf.String())
+	}
+}
@@ -0,0 +1,452 @@
+package jmdict
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// EDICT2 returns the entry as a line of the EDICT2 format, without the
+// trailing newline, for instance
+//
+//	食べる;喰べる(iK) [たべる(P)] /(v1,vt) (1) to eat/(2) to live on/(P)/EntL1358280/
+//
+// Forms carry their ke_inf or re_inf codes and "(P)" when common, and
+// readings restricted to some kebs list them in parentheses. Senses are
+// numbered when there is more than one, with the part-of-speech, field
+// in braces, misc and dialect codes, restrictions, sense information
+// and cross-references before the glosses. Only English glosses are
+// written; senses without any are left out, and the empty string is
+// returned if no sense remains.
+func (e *Entry) EDICT2() string {
+	type edictSense struct {
+		sense   *Sense
+		glosses []string
+	}
+	var senses []edictSense
+	for i := range e.Sense {
+		s := &e.Sense[i]
+		var glosses []string
+		for g, text := range s.Gloss {
+			if s.GlossLanguage(g) == DefaultGlossLang {
+				glosses = append(glosses, text)
+			}
+		}
+		if len(glosses) > 0 {
+			senses = append(senses, edictSense{s, glosses})
+		}
+	}
+	if len(senses) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	common := false
+	for i, k := range e.Kanji {
+		if i > 0 {
+			b.WriteByte(';')
+		}
+		b.WriteString(string(k.Phrase))
+		for _, o := range k.Info {
+			fmt.Fprintf(&b, "(%s)", o)
+		}
+		for _, p := range k.Priority {
+			if p.Common() {
+				b.WriteString("(P)")
+				common = true
+				break
+			}
+		}
+	}
+	if len(e.Kanji) > 0 {
+		b.WriteString(" [")
+	}
+	for i, r := range e.Reading {
+		if i > 0 {
+			b.WriteByte(';')
+		}
+		b.WriteString(string(r.Phrase))
+		if len(r.Restrict) > 0 {
+			restrict := make([]string, len(r.Restrict))
+			for j, k := range r.Restrict {
+				restrict[j] = string(k)
+			}
+			fmt.Fprintf(&b, "(%s)", strings.Join(restrict, ";"))
+		}
+		for _, o := range r.Orthography {
+			fmt.Fprintf(&b, "(%s)", o)
+		}
+		for _, p := range r.Priority {
+			if p.Common() {
+				b.WriteString("(P)")
+				common = true
+				break
+			}
+		}
+	}
+	if len(e.Kanji) > 0 {
+		b.WriteByte(']')
+	}
+	b.WriteString(" /")
+
+	for i, es := range senses {
+		s := es.sense
+		var tags []string
+		if len(s.Position) > 0 {
+			tags = append(tags, "("+strings.Join(stringSlice(s.Position), ",")+")")
+		}
+		if len(senses) > 1 {
+			tags = append(tags, fmt.Sprintf("(%d)", i+1))
+		}
+		for _, f := range s.Field {
+			tags = append(tags, "{"+string(f)+"}")
+		}
+		if len(s.Misc) > 0 {
+			tags = append(tags, "("+strings.Join(stringSlice(s.Misc), ",")+")")
+		}
+		for _, d := range s.Dialect {
+			tags = append(tags, "("+string(d)+":)")
+		}
+		restrict := append(append([]string(nil), s.KanjiRestrict...), s.ReadingRestrict...)
+		if len(restrict) > 0 {
+			tags = append(tags, "("+strings.Join(restrict, ",")+" only)")
+		}
+		for _, info := range s.Info {
+			tags = append(tags, "("+info+")")
+		}
+		for _, x := range s.Xref {
+			tags = append(tags, "(See "+x+")")
+		}
+		for _, a := range s.Antonym {
+			tags = append(tags, "(ant: "+a+")")
+		}
+		if len(tags) > 0 {
+			b.WriteString(strings.Join(tags, " "))
+			b.WriteByte(' ')
+		}
+		b.WriteString(strings.Join(es.glosses, "/"))
+		b.WriteByte('/')
+	}
+	if common {
+		b.WriteString("(P)/")
+	}
+	fmt.Fprintf(&b, "EntL%d/", e.Id)
+	return b.String()
+}
+
+// The header line written by WriteEDICT2.
+const edictHeader = "　？？？ /EDICT2, converted from JMdict/"
+
+// WriteEDICT2 writes the dictionary in the EDICT2 format: a header line,
+// then one line per entry as given by Entry.EDICT2. Entries without
+// English glosses are left out. The output is UTF-8, where the files
+// distributed by the EDRDG are EUC-JP.
+func WriteEDICT2(w io.Writer, dict JMDict) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(edictHeader)
+	bw.WriteByte('\n')
+	for i := range dict.Entries {
+		if line := dict.Entries[i].EDICT2(); line != "" {
+			bw.WriteString(line)
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+// ErrEDICTEncoding is returned by ReadEDICT2 for input which is not
+// UTF-8, such as the EUC-JP files distributed by the EDRDG.
+var ErrEDICTEncoding = errors.New("jmdict: EDICT2 input is not UTF-8")
+
+// ReadEDICT2 reads a dictionary in the EDICT2 format, as written by
+// WriteEDICT2. The input must be UTF-8, optionally with a byte order
+// mark. A header line, whose first field is "？？？", is skipped. Lines
+// without an EntL sequence number are numbered by their position in the
+// input.
+//
+// EDICT2 records less than JMdict. A "(P)" mark becomes the priority
+// spec1, so that the form is common, and parenthesised text at the
+// start of a sense which is not a known tag becomes s_inf. Glosses are
+// English.
+func ReadEDICT2(r io.Reader) (JMDict, error) {
+	dict := JMDict{XMLName: xml.Name{Local: "JMdict"}}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if !utf8.ValidString(text) {
+			return dict, fmt.Errorf("%w (line %d)", ErrEDICTEncoding, line)
+		}
+		if text == "" || strings.HasPrefix(strings.TrimLeft(text, "　 "), "？？？") {
+			continue
+		}
+		e, err := parseEDICT2(text)
+		if err != nil {
+			return dict, fmt.Errorf("jmdict: EDICT2 line %d: %v", line, err)
+		}
+		if e.Id == 0 {
+			e.Id = EntSeq(line)
+		}
+		dict.Entries = append(dict.Entries, e)
+	}
+	return dict, scanner.Err()
+}
+
+// The priority given to forms marked "(P)".
+const edictCommon = "spec1"
+
+func parseEDICT2(line string) (Entry, error) {
+	var e Entry
+	slash := strings.Index(line, "/")
+	if slash < 0 {
+		return e, errors.New("no glosses")
+	}
+	head, body := strings.TrimSpace(line[:slash]), strings.Trim(line[slash:], "/")
+
+	kana := head
+	if open := strings.Index(head, "["); open >= 0 {
+		close := strings.LastIndex(head, "]")
+		if close < open {
+			return e, errors.New("unbalanced [ in headword")
+		}
+		for _, form := range splitEDICTForms(strings.TrimSpace(head[:open])) {
+			text, tags := splitEDICTTags(form)
+			k := KEle{Phrase: Keb(text)}
+			for _, t := range tags {
+				if t == "P" {
+					k.Priority = append(k.Priority, KePriority{Priority: Priority{Raw: edictCommon}})
+				} else {
+					k.Info = append(k.Info, Orthography(t))
+				}
+			}
+			e.Kanji = append(e.Kanji, k)
+		}
+		kana = strings.TrimSpace(head[open+1 : close])
+	}
+	for _, form := range splitEDICTForms(kana) {
+		text, tags := splitEDICTTags(form)
+		r := REle{Phrase: Reb(text)}
+		for _, t := range tags {
+			switch {
+			case t == "P":
+				r.Priority = append(r.Priority, RePriority{Priority: Priority{Raw: edictCommon}})
+			case orthographyDescriptions[Orthography(t)] != "":
+				r.Orthography = append(r.Orthography, Orthography(t))
+			default:
+				for _, k := range strings.Split(t, ";") {
+					r.Restrict = append(r.Restrict, ReRestr(k))
+				}
+			}
+		}
+		e.Reading = append(e.Reading, r)
+	}
+	if len(e.Reading) == 0 {
+		return e, errors.New("no reading")
+	}
+
+	var sense *Sense
+	common := false
+	for _, field := range strings.Split(body, "/") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if field == "(P)" {
+			common = true
+			continue
+		}
+		if strings.HasPrefix(field, "EntL") {
+			seq := strings.TrimRight(field[len("EntL"):], "X")
+			n, err := strconv.ParseUint(seq, 10, 64)
+			if err != nil {
+				return e, fmt.Errorf("bad sequence number %q", field)
+			}
+			e.Id = EntSeq(n)
+			continue
+		}
+
+		var s Sense
+		gloss, numbered := parseEDICTSenseTags(field, &s, &e, sense == nil)
+		if sense == nil || numbered {
+			e.Sense = append(e.Sense, s)
+			sense = &e.Sense[len(e.Sense)-1]
+		} else if len(s.Position)+len(s.Misc)+len(s.Field)+len(s.Dialect) > 0 {
+			// Tags part way through an unnumbered list of glosses
+			// belong to a new sense.
+			e.Sense = append(e.Sense, s)
+			sense = &e.Sense[len(e.Sense)-1]
+		}
+		if gloss != "" {
+			sense.addGloss(gloss, DefaultGlossLang)
+		}
+	}
+	if len(e.Sense) == 0 {
+		return e, errors.New("no senses")
+	}
+	if common && !edictMarked(&e) {
+		// The entry is common but no form says which.
+		if len(e.Kanji) > 0 {
+			e.Kanji[0].Priority = append(e.Kanji[0].Priority, KePriority{Priority: Priority{Raw: edictCommon}})
+		} else {
+			e.Reading[0].Priority = append(e.Reading[0].Priority, RePriority{Priority: Priority{Raw: edictCommon}})
+		}
+	}
+	return e, nil
+}
+
+// Reports whether any form of the entry has a priority.
+func edictMarked(e *Entry) bool {
+	for _, k := range e.Kanji {
+		if len(k.Priority) > 0 {
+			return true
+		}
+	}
+	for _, r := range e.Reading {
+		if len(r.Priority) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Splits a list of forms separated by semicolons, ignoring semicolons
+// within parentheses.
+func splitEDICTForms(list string) []string {
+	var forms []string
+	depth, start := 0, 0
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ';':
+			if depth == 0 {
+				forms = append(forms, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if rest := list[start:]; rest != "" {
+		forms = append(forms, rest)
+	}
+	return forms
+}
+
+// Splits a form into its text and the contents of its parenthesised
+// tags, so that "こ(子供)(P)" gives "こ" and ["子供", "P"].
+func splitEDICTTags(form string) (string, []string) {
+	i := strings.Index(form, "(")
+	if i < 0 {
+		return strings.TrimSpace(form), nil
+	}
+	text, rest := strings.TrimSpace(form[:i]), form[i:]
+	var tags []string
+	for strings.HasPrefix(rest, "(") {
+		close := strings.Index(rest, ")")
+		if close < 0 {
+			break
+		}
+		tags = append(tags, rest[1:close])
+		rest = rest[close+1:]
+	}
+	return text, tags
+}
+
+// Moves the tags at the start of a gloss field into s, and returns the
+// rest of the field and whether the tags included a sense number.
+// Unknown tags are taken as sense information when they start a sense,
+// and are otherwise left in the gloss.
+func parseEDICTSenseTags(field string, s *Sense, e *Entry, first bool) (string, bool) {
+	rest := field
+	numbered := false
+	var info []string
+	for len(rest) > 0 && (rest[0] == '(' || rest[0] == '{') {
+		closer := ")"
+		if rest[0] == '{' {
+			closer = "}"
+		}
+		end := strings.Index(rest, closer)
+		if end < 0 {
+			break
+		}
+		tag := rest[1:end]
+		next := strings.TrimSpace(rest[end+1:])
+
+		codes := strings.Split(tag, ",")
+		switch {
+		case rest[0] == '{':
+			for _, c := range codes {
+				s.Field = append(s.Field, Field(c))
+			}
+		case isNumber(tag):
+			numbered = true
+		case allCodes(codes, func(c string) bool { return positionDescriptions[Position(c)] != "" }):
+			for _, c := range codes {
+				s.Position = append(s.Position, Position(c))
+			}
+		case allCodes(codes, func(c string) bool { return miscDescriptions[Misc(c)] != "" }):
+			for _, c := range codes {
+				s.Misc = append(s.Misc, Misc(c))
+			}
+		case allCodes(codes, func(c string) bool { return fieldDescriptions[Field(c)] != "" }):
+			for _, c := range codes {
+				s.Field = append(s.Field, Field(c))
+			}
+		case strings.HasSuffix(tag, ":") && dialectDescriptions[Dialect(strings.TrimSuffix(tag, ":"))] != "":
+			s.Dialect = append(s.Dialect, Dialect(strings.TrimSuffix(tag, ":")))
+		case strings.HasSuffix(tag, " only"):
+			for _, form := range strings.Split(strings.TrimSuffix(tag, " only"), ",") {
+				form = strings.TrimSpace(form)
+				if e.hasReading(form) {
+					s.ReadingRestrict = append(s.ReadingRestrict, form)
+				} else {
+					s.KanjiRestrict = append(s.KanjiRestrict, form)
+				}
+			}
+		case strings.HasPrefix(tag, "See "):
+			s.Xref = append(s.Xref, strings.TrimPrefix(tag, "See "))
+		case strings.HasPrefix(tag, "ant: "):
+			s.Antonym = append(s.Antonym, strings.TrimPrefix(tag, "ant: "))
+		default:
+			if next == "" {
+				// The whole gloss is parenthesised.
+				return strings.TrimSpace(rest), numbered
+			}
+			info = append(info, tag)
+		}
+		rest = next
+	}
+	if len(info) > 0 {
+		if first || numbered {
+			s.Info = append(s.Info, info...)
+		} else {
+			rest = "(" + strings.Join(info, ") (") + ") " + rest
+		}
+	}
+	return rest, numbered
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func allCodes(codes []string, known func(string) bool) bool {
+	for _, c := range codes {
+		if !known(c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package jmdict
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEDICT2(t *testing.T) {
+	dict := readSample(t)
+	tests := []struct {
+		seq  EntSeq
+		line string
+	}{
+		{1358280, "食べる(P);喰べる(iK) [たべる(P)] /(v1,vt) (1) (See 食う・1) to eat/(2) to live on (e.g. a salary)/to live off/(P)/EntL1358280/"},
+		{1591900, "子供(P);子ども(P);小供(oK) [こども(P);こ(子供)] /(n) (1) (uk) child/children/(2) {Buddh} (arch) (ksb:) (こども only) offspring/(P)/EntL1591900/"},
+		{1080180, "倫敦(ateji) [ロンドン(P);ロンドン] /(n) London/(P)/EntL1080180/"},
+		{1512360, "勉強(P) [べんきょう(P)] /(n,vs) (1) (also used of training) study/(n) (2) (col) diligence/(P)/EntL1512360/"},
+	}
+	for _, test := range tests {
+		if got := sampleEntry(t, dict, test.seq).EDICT2(); got != test.line {
+			t.Errorf("EDICT2 of %d:\n%s\nwant:\n%s", test.seq, got, test.line)
+		}
+	}
+
+	// Entries without English glosses are left out.
+	e := Entry{Id: 1, Reading: []REle{{Phrase: "て"}}}
+	e.Sense = []Sense{{Gloss: []string{"main"}, GlossLang: []string{"fre"}}}
+	if got := e.EDICT2(); got != "" {
+		t.Errorf("EDICT2 of an entry with only French glosses = %q", got)
+	}
+}
+
+func TestReadEDICT2(t *testing.T) {
+	dict := readSample(t)
+	var buf bytes.Buffer
+	if err := WriteEDICT2(&buf, dict); err != nil {
+		t.Fatal(err)
+	}
+	written := buf.String()
+	if !strings.HasPrefix(written, edictHeader+"\n") {
+		t.Errorf("output does not start with the header: %.40q", written)
+	}
+
+	read, err := ReadEDICT2(strings.NewReader("\ufeff" + written))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Entries) != len(dict.Entries) {
+		t.Fatalf("read %d entries, want %d", len(read.Entries), len(dict.Entries))
+	}
+	for i := range dict.Entries {
+		want, got := dict.Entries[i].EDICT2(), read.Entries[i].EDICT2()
+		if got != want {
+			t.Errorf("round trip of %d:\n%s\nwant:\n%s", dict.Entries[i].Id, got, want)
+		}
+	}
+
+	// Lines without EntL are numbered by position.
+	read, err = ReadEDICT2(strings.NewReader("食べる [たべる] /(v1) to eat/\n高い [たかい] /(adj-i) high/\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := entrySeqs(read.Entries); len(got) != 2 || got[0] == got[1] {
+		t.Errorf("read sequence numbers %v, want two distinct", got)
+	}
+	if _, err := ReadEDICT2(strings.NewReader("\xb0\xa1 [\xa4\xa2] /a/\n")); !errors.Is(err, ErrEDICTEncoding) {
+		t.Errorf("reading EUC-JP: %v, want ErrEDICTEncoding", err)
+	}
+}
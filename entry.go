@@ -0,0 +1,181 @@
+package jmdict
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A Pair is a kanji element together with a reading element which
+// may be used with it. Kanji is nil for readings which stand alone,
+// either because the entry has no kanji elements or because the
+// reading is marked re_nokanji.
+type Pair struct {
+	Kanji   *KEle
+	Reading *REle
+
+	// Set by Entry.Pairs when no keb of the entry has ke_pri, so that
+	// the reading's priorities apply to the kanji.
+	readingPriority bool
+}
+
+// Headword returns the kanji phrase of the pair, or the reading when
+// the pair has no kanji. It returns the empty string for the zero Pair,
+// which Entry.Headword returns for an entry without readings.
+func (p Pair) Headword() string {
+	if p.Kanji != nil {
+		return string(p.Kanji.Phrase)
+	}
+	if p.Reading != nil {
+		return string(p.Reading.Phrase)
+	}
+	return ""
+}
+
+// String returns the pair as dictionaries write it, with the reading
+// after the kanji in brackets, for instance 食べる【たべる】. A pair
+// without kanji is written as its reading, and the zero Pair as the
+// empty string.
+func (p Pair) String() string {
+	if p.Kanji == nil || p.Reading == nil {
+		return p.Headword()
+	}
+	return string(p.Kanji.Phrase) + "【" + string(p.Reading.Phrase) + "】"
+}
+
+// Pairs returns every valid kanji/reading combination of the entry
+// in document order, honouring re_restr and re_nokanji.
+func (e *Entry) Pairs() []Pair {
+	var pairs []Pair
+	readingPriority := true
+	for _, k := range e.Kanji {
+		if len(k.Priority) > 0 {
+			readingPriority = false
+		}
+	}
+	for i := range e.Reading {
+		r := &e.Reading[i]
+		if len(e.Kanji) == 0 || r.ImproperReading != nil {
+			pairs = append(pairs, Pair{Reading: r})
+			continue
+		}
+		for j := range e.Kanji {
+			k := &e.Kanji[j]
+			if r.AppliesTo(k.Phrase) {
+				pairs = append(pairs, Pair{Kanji: k, Reading: r, readingPriority: readingPriority})
+			}
+		}
+	}
+	return pairs
+}
+
+// AppliesTo reports whether the reading may be used with the given
+// kanji phrase according to its re_restr and re_nokanji elements.
+func (r *REle) AppliesTo(keb Keb) bool {
+	if r.ImproperReading != nil {
+		return false
+	}
+	if len(r.Restrict) == 0 {
+		return true
+	}
+	for _, restr := range r.Restrict {
+		if string(restr) == string(keb) {
+			return true
+		}
+	}
+	return false
+}
+
+// AppliesTo reports whether the sense may be used with the pair
+// according to its stagk and stagr elements.
+func (s *Sense) AppliesTo(p Pair) bool {
+	if p.Kanji != nil && len(s.KanjiRestrict) > 0 &&
+		!containsString(s.KanjiRestrict, string(p.Kanji.Phrase)) {
+		return false
+	}
+	if len(s.ReadingRestrict) > 0 &&
+		!containsString(s.ReadingRestrict, string(p.Reading.Phrase)) {
+		return false
+	}
+	return true
+}
+
+// Positions returns the part-of-speech of the i'th sense. Senses without
+// a pos element inherit the part-of-speech of the closest earlier sense
+// which has one.
+func (e *Entry) Positions(i int) []Position {
+	for ; i >= 0; i-- {
+		if len(e.Sense[i].Position) > 0 {
+			return e.Sense[i].Position
+		}
+	}
+	return nil
+}
+
+// Priorities returns the priorities which apply to the pair, with Code
+// and Rank filled in. Since a priority may be recorded against only a
+// particular kanji/reading pair, a kanji priority is dropped when the
+// reading carries priorities but not that one. When no kanji of the
+// entry carries priorities, as for words usually written in kana such
+// as 此れ, whose priorities are recorded against これ, the reading's
+// apply to every kanji of a pair from Entry.Pairs. Otherwise a kanji
+// without priorities, such as the irregular 喰べる beside 食べる, has
+// none.
+func (p Pair) Priorities() []Priority {
+	var pris []Priority
+	if p.Kanji == nil || p.readingPriority {
+		for _, pri := range p.Reading.Priority {
+			pris = append(pris, pri.Priority.Parse())
+		}
+		return pris
+	}
+	for _, pri := range p.Kanji.Priority {
+		if len(p.Reading.Priority) == 0 || p.Reading.hasPriority(pri.Raw) {
+			pris = append(pris, pri.Priority.Parse())
+		}
+	}
+	return pris
+}
+
+func (r *REle) hasPriority(raw string) bool {
+	for _, pri := range r.Priority {
+		if pri.Raw == raw {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse returns a copy of the priority with Code and Rank filled in
+// from Raw. Raw values which do not end in a number are returned with
+// a Rank of zero.
+func (p Priority) Parse() Priority {
+	raw := strings.TrimSpace(p.Raw)
+	i := strings.IndexAny(raw, "0123456789")
+	if i < 0 {
+		p.Code, p.Rank = PriorityCode(raw), 0
+		return p
+	}
+	p.Code = PriorityCode(raw[:i])
+	p.Rank, _ = strconv.Atoi(raw[i:])
+	return p
+}
+
+// Common reports whether the priority would mark the entry with a
+// "(P)" in the EDICT files, that is news1, ichi1, spec1 or gai1.
+func (p Priority) Common() bool {
+	p = p.Parse()
+	switch p.Code {
+	case Newspaper, BunruiShuu, LoanWord, Special:
+		return p.Rank == 1
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
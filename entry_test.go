@@ -0,0 +1,32 @@
+package jmdict
+
+import "testing"
+
+func TestPairString(t *testing.T) {
+	dict := readSample(t)
+	e := sampleEntry(t, dict, 1358280)
+	k, r := &e.Kanji[0], &e.Reading[0]
+	tests := []struct {
+		pair          Pair
+		str, headword string
+	}{
+		{Pair{Kanji: k, Reading: r}, "食べる【たべる】", "食べる"},
+		{Pair{Reading: r}, "たべる", "たべる"},
+		{Pair{Kanji: k}, "食べる", "食べる"},
+		{Pair{}, "", ""},
+	}
+	for _, test := range tests {
+		if got := test.pair.String(); got != test.str {
+			t.Errorf("String() = %q, want %q", got, test.str)
+		}
+		if got := test.pair.Headword(); got != test.headword {
+			t.Errorf("Headword() = %q, want %q", got, test.headword)
+		}
+	}
+
+	// An entry without readings has no headword pair.
+	head, _ := (&Entry{Kanji: []KEle{{Phrase: "某"}}}).Headword()
+	if head.String() != "" {
+		t.Errorf("headword of an entry without readings = %q", head.String())
+	}
+}
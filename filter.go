@@ -0,0 +1,259 @@
+package jmdict
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// A Filter selects entries.
+type Filter func(*Entry) bool
+
+// Subset returns a dictionary holding the entries of d which match f,
+// in their original order. The entries are shared with d.
+func (d JMDict) Subset(f Filter) JMDict {
+	subset := JMDict{XMLName: d.XMLName}
+	for i := range d.Entries {
+		if f(&d.Entries[i]) {
+			subset.Entries = append(subset.Entries, d.Entries[i])
+		}
+	}
+	return subset
+}
+
+// And matches entries which match every filter.
+func And(filters ...Filter) Filter {
+	return func(e *Entry) bool {
+		for _, f := range filters {
+			if !f(e) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches entries which match any filter.
+func Or(filters ...Filter) Filter {
+	return func(e *Entry) bool {
+		for _, f := range filters {
+			if f(e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches entries which do not match f.
+func Not(f Filter) Filter {
+	return func(e *Entry) bool {
+		return !f(e)
+	}
+}
+
+// HasPriority matches entries with a ke_pri or re_pri equal to raw, for
+// instance "news1" or "nf12".
+func HasPriority(raw string) Filter {
+	return priorityFilter(func(p Priority) bool {
+		return p.Raw == raw
+	})
+}
+
+// HasPriorityCode matches entries with a priority of the given code and
+// a rank no greater than maxRank. A maxRank of zero matches any rank.
+func HasPriorityCode(code PriorityCode, maxRank int) Filter {
+	return priorityFilter(func(p Priority) bool {
+		return p.Code == code && (maxRank == 0 || p.Rank <= maxRank)
+	})
+}
+
+// IsCommon matches entries marked "(P)" in EDICT, see Priority.Common.
+func IsCommon() Filter {
+	return priorityFilter(Priority.Common)
+}
+
+func priorityFilter(match func(Priority) bool) Filter {
+	return func(e *Entry) bool {
+		for _, k := range e.Kanji {
+			for _, p := range k.Priority {
+				if match(p.Priority.Parse()) {
+					return true
+				}
+			}
+		}
+		for _, r := range e.Reading {
+			for _, p := range r.Priority {
+				if match(p.Priority.Parse()) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// HasPosition matches entries with a sense whose part-of-speech matches
+// pattern, which may contain path.Match wildcards, for instance "v5*".
+func HasPosition(pattern string) Filter {
+	return senseFilter(pattern, func(s *Sense) []string { return stringSlice(s.Position) })
+}
+
+// HasField matches entries with a sense whose field matches pattern.
+func HasField(pattern string) Filter {
+	return senseFilter(pattern, func(s *Sense) []string { return stringSlice(s.Field) })
+}
+
+// HasMisc matches entries with a sense whose misc matches pattern.
+func HasMisc(pattern string) Filter {
+	return senseFilter(pattern, func(s *Sense) []string { return stringSlice(s.Misc) })
+}
+
+// HasDialect matches entries with a sense whose dialect matches pattern.
+func HasDialect(pattern string) Filter {
+	return senseFilter(pattern, func(s *Sense) []string { return stringSlice(s.Dialect) })
+}
+
+// HasGlossLang matches entries with a gloss in the given language, an
+// ISO 639-2 code such as "eng" or "fre".
+func HasGlossLang(lang string) Filter {
+	return senseFilter(lang, func(s *Sense) []string {
+		langs := make([]string, len(s.Gloss))
+		for g := range s.Gloss {
+			langs[g] = s.GlossLanguage(g)
+		}
+		return langs
+	})
+}
+
+func senseFilter(pattern string, values func(*Sense) []string) Filter {
+	return func(e *Entry) bool {
+		for i := range e.Sense {
+			for _, v := range values(&e.Sense[i]) {
+				if ok, _ := path.Match(pattern, v); ok {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// HasKanji matches entries with at least one kanji element.
+func HasKanji() Filter {
+	return func(e *Entry) bool {
+		return len(e.Kanji) > 0
+	}
+}
+
+// SeqRange matches entries with a sequence number in [from, to].
+func SeqRange(from, to EntSeq) Filter {
+	return func(e *Entry) bool {
+		return e.Id >= from && e.Id <= to
+	}
+}
+
+// ParseFilter compiles a query into a Filter. A query is a list of
+// space separated terms, all of which must match. Each term is a
+// key:value pair, and is negated by a leading "-". A value may list
+// alternatives separated by commas, and tag values may use path.Match
+// wildcards. The keys are
+//
+//	pri:news1    ke_pri or re_pri equal to news1
+//	pri:news     any news rank
+//	pri:nf<=12   nf rank no greater than 12
+//	pri:common   news1, ichi1, spec1 or gai1
+//	pos:v5*      part-of-speech
+//	field:comp   field of application
+//	misc:arch    misc information
+//	dial:ksb     dialect
+//	lang:fre     gloss language
+//	has:kanji    has a kanji element
+//	seq:1000000-1999999
+//
+// For example "pri:news1 pos:v5* -misc:arch".
+func ParseFilter(query string) (Filter, error) {
+	var filters []Filter
+	for _, term := range strings.Fields(query) {
+		negate := strings.HasPrefix(term, "-")
+		term = strings.TrimPrefix(term, "-")
+		i := strings.Index(term, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("jmdict: filter term %q is not key:value", term)
+		}
+		key, value := term[:i], term[i+1:]
+
+		var alts []Filter
+		for _, v := range strings.Split(value, ",") {
+			f, err := parseFilterTerm(key, v)
+			if err != nil {
+				return nil, err
+			}
+			alts = append(alts, f)
+		}
+		f := alts[0]
+		if len(alts) > 1 {
+			f = Or(alts...)
+		}
+		if negate {
+			f = Not(f)
+		}
+		filters = append(filters, f)
+	}
+	return And(filters...), nil
+}
+
+func parseFilterTerm(key, value string) (Filter, error) {
+	if value == "" {
+		return nil, fmt.Errorf("jmdict: filter term %s: has no value", key)
+	}
+	if key != "seq" {
+		if _, err := path.Match(value, ""); err != nil {
+			return nil, fmt.Errorf("jmdict: filter term %s:%s: %v", key, value, err)
+		}
+	}
+	switch key {
+	case "pri":
+		if value == "common" {
+			return IsCommon(), nil
+		}
+		if i := strings.Index(value, "<="); i >= 0 {
+			rank, err := strconv.Atoi(value[i+2:])
+			if err != nil {
+				return nil, fmt.Errorf("jmdict: filter term pri:%s: bad rank", value)
+			}
+			return HasPriorityCode(PriorityCode(value[:i]), rank), nil
+		}
+		if p := (Priority{Raw: value}).Parse(); p.Rank == 0 {
+			return HasPriorityCode(p.Code, 0), nil
+		}
+		return HasPriority(value), nil
+	case "pos":
+		return HasPosition(value), nil
+	case "field":
+		return HasField(value), nil
+	case "misc":
+		return HasMisc(value), nil
+	case "dial":
+		return HasDialect(value), nil
+	case "lang":
+		return HasGlossLang(value), nil
+	case "has":
+		if value == "kanji" {
+			return HasKanji(), nil
+		}
+	case "seq":
+		bounds := strings.SplitN(value, "-", 2)
+		from, err := strconv.ParseUint(bounds[0], 10, 64)
+		to := from
+		if err == nil && len(bounds) == 2 {
+			to, err = strconv.ParseUint(bounds[1], 10, 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jmdict: filter term seq:%s: bad range", value)
+		}
+		return SeqRange(EntSeq(from), EntSeq(to)), nil
+	}
+	return nil, fmt.Errorf("jmdict: unknown filter term %s:%s", key, value)
+}
@@ -0,0 +1,43 @@
+package jmdict
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	dict := readSample(t)
+	tests := []struct {
+		query string
+		seqs  []EntSeq
+	}{
+		{"pos:v5* -misc:arch", []EntSeq{1578850}},
+		{"misc:arch", []EntSeq{1591900}},
+		{"field:Buddh dial:ksb", []EntSeq{1591900}},
+		{"pos:adj-*", []EntSeq{1000220, 1280640}},
+		{"pri:gai1", []EntSeq{1080180}},
+		{"-pri:nf", []EntSeq{1080180}},
+		{"pri:nf<=2", []EntSeq{1628530, 1578850, 1280640}},
+		{"lang:ger,fre", []EntSeq{1000220, 1358280, 1628530, 1512360}},
+		{"seq:1500000-1599999", []EntSeq{1591900, 1578850, 1512360}},
+		{"seq:1358280", []EntSeq{1358280}},
+		{"pri:common has:kanji", entrySeqs(dict.Entries)},
+		{"", entrySeqs(dict.Entries)},
+	}
+	for _, test := range tests {
+		f, err := ParseFilter(test.query)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", test.query, err)
+			continue
+		}
+		if got := entrySeqs(dict.Subset(f).Entries); !reflect.DeepEqual(got, test.seqs) {
+			t.Errorf("ParseFilter(%q) matched %v, want %v", test.query, got, test.seqs)
+		}
+	}
+
+	for _, query := range []string{"pos", "pos:", "pos:[", "pri:nf<=x", "seq:1-x", "has:reading", "foo:bar"} {
+		if _, err := ParseFilter(query); err == nil {
+			t.Errorf("ParseFilter(%q) succeeded", query)
+		}
+	}
+}
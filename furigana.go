@@ -0,0 +1,60 @@
+package jmdict
+
+// A Furigana segment is a run of text from a keb together with the part
+// of the reb which is read over it. Reading is empty for segments which
+// are already written in kana.
+type Furigana struct {
+	Text    string
+	Reading string
+}
+
+// Align splits the pair's keb into kana and non-kana runs and assigns
+// each non-kana run the part of the reb it is read as, by matching the
+// kana runs of the keb against the reb. When no alignment exists, or the
+// pair has no kanji, a single segment covering the whole phrase is
+// returned.
+func (p Pair) Align() []Furigana {
+	if p.Kanji == nil {
+		return []Furigana{{Text: string(p.Reading.Phrase)}}
+	}
+	keb := []rune(string(p.Kanji.Phrase))
+	reb := []rune(string(p.Reading.Phrase))
+
+	var runs [][]rune
+	for i := 0; i < len(keb); {
+		j := i + 1
+		for j < len(keb) && isKana(keb[j]) == isKana(keb[i]) {
+			j++
+		}
+		runs = append(runs, keb[i:j])
+		i = j
+	}
+
+	if segs, ok := alignRuns(runs, reb); ok {
+		return segs
+	}
+	return []Furigana{{Text: string(keb), Reading: string(reb)}}
+}
+
+func alignRuns(runs [][]rune, reb []rune) ([]Furigana, bool) {
+	if len(runs) == 0 {
+		return nil, len(reb) == 0
+	}
+	run := runs[0]
+	if isKana(run[0]) {
+		if len(reb) < len(run) || !kanaEqual(run, reb[:len(run)]) {
+			return nil, false
+		}
+		rest, ok := alignRuns(runs[1:], reb[len(run):])
+		return append([]Furigana{{Text: string(run)}}, rest...), ok
+	}
+	// Every non-kana run is read as at least one kana. Prefer the
+	// shortest reading which lets the remainder align.
+	for n := 1; n <= len(reb); n++ {
+		if rest, ok := alignRuns(runs[1:], reb[n:]); ok {
+			seg := Furigana{Text: string(run), Reading: string(reb[:n])}
+			return append([]Furigana{seg}, rest...), true
+		}
+	}
+	return nil, false
+}
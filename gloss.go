@@ -0,0 +1,109 @@
+package jmdict
+
+import "encoding/xml"
+
+// Language of glosses which have no xml:lang attribute
+const DefaultGlossLang = "eng"
+
+// GlossLanguage returns the language of the i'th gloss of s. Senses
+// built without GlossLang, or with an empty language, have glosses in
+// DefaultGlossLang.
+func (s *Sense) GlossLanguage(i int) string {
+	if i < len(s.GlossLang) && s.GlossLang[i] != "" {
+		return s.GlossLang[i]
+	}
+	return DefaultGlossLang
+}
+
+// Appends a gloss in the given language, or in DefaultGlossLang if lang
+// is empty.
+func (s *Sense) addGloss(text, lang string) {
+	if lang == "" {
+		lang = DefaultGlossLang
+	}
+	s.Gloss = append(s.Gloss, text)
+	s.GlossLang = append(s.GlossLang, lang)
+}
+
+// UnmarshalXML decodes a sense element, recording the language of each
+// gloss in GlossLang.
+func (s *Sense) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// A copy of Sense without this method. The name is exported so
+	// that encoding/xml can decode into the embedded fields.
+	type Fields Sense
+	var raw struct {
+		Fields
+		Gloss []struct {
+			Lang string `xml:"lang,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"gloss"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	*s = Sense(raw.Fields)
+	s.XMLName = start.Name
+	for _, g := range raw.Gloss {
+		s.addGloss(g.Text, g.Lang)
+	}
+	return nil
+}
+
+// A glossFilter passes on the tokens of a decoder, leaving out gloss
+// elements in languages other than those wanted, so that they are never
+// decoded.
+type glossFilter struct {
+	d     *xml.Decoder
+	langs map[string]bool
+}
+
+func (f glossFilter) Token() (xml.Token, error) {
+	for {
+		tok, err := f.d.Token()
+		if err != nil {
+			return tok, err
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "gloss" {
+			if !f.langs[glossLang(start)] {
+				if err := f.d.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		return tok, nil
+	}
+}
+
+// Returns the xml:lang attribute of a gloss element.
+func glossLang(start xml.StartElement) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "lang" && attr.Value != "" {
+			return attr.Value
+		}
+	}
+	return DefaultGlossLang
+}
+
+// Drops senses without glosses. A part-of-speech given by a dropped
+// sense is moved to the next remaining sense if that sense would
+// otherwise inherit it, so that Positions is unchanged for the remaining
+// senses.
+func (e *Entry) dropEmptySenses() {
+	senses := e.Sense[:0]
+	var pending []Position
+	for _, s := range e.Sense {
+		if len(s.Gloss) == 0 {
+			if len(s.Position) > 0 {
+				pending = s.Position
+			}
+			continue
+		}
+		if len(s.Position) == 0 && pending != nil {
+			s.Position = pending
+		}
+		pending = nil
+		senses = append(senses, s)
+	}
+	e.Sense = senses
+}
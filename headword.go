@@ -0,0 +1,94 @@
+package jmdict
+
+import "sort"
+
+// Orthography which marks a form as unsuitable for display when another
+// form is available.
+var demotedOrthography = map[Orthography]bool{
+	Ateji:                   true,
+	IrregularKanji:          true,
+	OutdatedKanji:           true,
+	IrregularKana:           true,
+	OutdatedKana:            true,
+	OutdatedOrIrregularKana: true,
+}
+
+// Headword returns the form of the entry to display and the remaining
+// forms in order of preference. The forms are ordered by
+//
+//   - kana before kanji when the first sense is marked uk (KanaAlone)
+//   - forms without ateji, irregular or outdated orthography first
+//   - higher PriorityScore first
+//   - document order
+//
+// Readings marked re_nokanji are never paired with kanji. When kana is
+// preferred, each reading is also offered on its own, as a Pair with a
+// nil Kanji.
+func (e *Entry) Headword() (Pair, []Pair) {
+	pairs := e.Pairs()
+	kana := len(e.Sense) > 0 && len(e.Kanji) > 0 && hasMisc(e.Sense[0].Misc, KanaAlone)
+	if kana {
+		for i := range e.Reading {
+			if e.Reading[i].ImproperReading == nil {
+				pairs = append(pairs, Pair{Reading: &e.Reading[i]})
+			}
+		}
+	}
+	if len(pairs) == 0 {
+		return Pair{}, nil
+	}
+
+	type ranked struct {
+		pair     Pair
+		kana     bool
+		demoted  bool
+		priority int
+	}
+	forms := make([]ranked, len(pairs))
+	for i, p := range pairs {
+		forms[i] = ranked{pair: p, kana: p.Kanji == nil,
+			demoted: p.demoted(), priority: PriorityScore(p.Priorities())}
+	}
+	sort.SliceStable(forms, func(i, j int) bool {
+		a, b := forms[i], forms[j]
+		if kana && a.kana != b.kana {
+			return a.kana
+		}
+		if a.demoted != b.demoted {
+			return !a.demoted
+		}
+		return a.priority > b.priority
+	})
+
+	alternates := make([]Pair, len(forms)-1)
+	for i := range alternates {
+		alternates[i] = forms[i+1].pair
+	}
+	return forms[0].pair, alternates
+}
+
+// Reports whether either element of the pair has demoted orthography.
+func (p Pair) demoted() bool {
+	if p.Kanji != nil {
+		for _, o := range p.Kanji.Info {
+			if demotedOrthography[o] {
+				return true
+			}
+		}
+	}
+	for _, o := range p.Reading.Orthography {
+		if demotedOrthography[o] {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMisc(list []Misc, m Misc) bool {
+	for _, x := range list {
+		if x == m {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package jmdict
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Returns the headword and alternates of e as strings.
+func headwordStrings(e *Entry) (string, []string) {
+	head, alternates := e.Headword()
+	forms := make([]string, len(alternates))
+	for i, p := range alternates {
+		forms[i] = p.String()
+	}
+	return head.String(), forms
+}
+
+func TestHeadword(t *testing.T) {
+	dict := readSample(t)
+	tests := []struct {
+		seq        EntSeq
+		head       string
+		alternates []string
+	}{
+		{1000220, "明白【めいはく】", []string{}},
+		// 喰べる is iK.
+		{1358280, "食べる【たべる】", []string{"喰べる【たべる】"}},
+		// uk: the readings come first, then the kanji, with 小供 (oK) last.
+		{1591900, "こども", []string{"こ", "子供【こども】", "子供【こ】", "子ども【こども】", "小供【こども】"}},
+		// 倫敦 is ateji, and the second ロンドン is re_nokanji.
+		{1080180, "ロンドン", []string{"倫敦【ロンドン】"}},
+		{1628530, "これ", []string{"此れ【これ】", "是【これ】"}},
+		{1578850, "行く【いく】", []string{"行く【ゆく】"}},
+	}
+	for _, test := range tests {
+		head, alternates := headwordStrings(sampleEntry(t, dict, test.seq))
+		if head != test.head || !reflect.DeepEqual(alternates, test.alternates) {
+			t.Errorf("%d: Headword() = %s, %q; want %s, %q", test.seq, head, alternates, test.head, test.alternates)
+		}
+	}
+}
+
+func TestHeadwordOrder(t *testing.T) {
+	keb := func(phrase string, info Orthography, pri string) KEle {
+		k := KEle{Phrase: Keb(phrase)}
+		if info != "" {
+			k.Info = []Orthography{info}
+		}
+		if pri != "" {
+			k.Priority = []KePriority{{Priority: Priority{Raw: pri}}}
+		}
+		return k
+	}
+	reb := func(phrase string, info Orthography) REle {
+		r := REle{Phrase: Reb(phrase)}
+		if info != "" {
+			r.Orthography = []Orthography{info}
+		}
+		return r
+	}
+	uk := []Sense{{Misc: []Misc{KanaAlone}, Gloss: []string{"x"}}}
+	tests := []struct {
+		name       string
+		entry      Entry
+		head       string
+		alternates []string
+	}{
+		{"document order",
+			Entry{Kanji: []KEle{keb("甲", "", ""), keb("乙", "", "")}, Reading: []REle{reb("かな", "")}},
+			"甲【かな】", []string{"乙【かな】"}},
+		{"priority",
+			Entry{Kanji: []KEle{keb("甲", "", ""), keb("乙", "", "news1")}, Reading: []REle{reb("かな", "")}},
+			"乙【かな】", []string{"甲【かな】"}},
+		{"demoted before priority",
+			Entry{Kanji: []KEle{keb("甲", IrregularKanji, "ichi1"), keb("乙", "", "")}, Reading: []REle{reb("かな", "")}},
+			"乙【かな】", []string{"甲【かな】"}},
+		{"ateji",
+			Entry{Kanji: []KEle{keb("甲", Ateji, ""), keb("乙", OutdatedKanji, ""), keb("丙", "", "")},
+				Reading: []REle{reb("かな", "")}},
+			"丙【かな】", []string{"甲【かな】", "乙【かな】"}},
+		{"uk",
+			Entry{Kanji: []KEle{keb("甲", "", "news1")}, Reading: []REle{reb("かな", "")}, Sense: uk},
+			"かな", []string{"甲【かな】"}},
+		{"uk with demoted kana",
+			Entry{Kanji: []KEle{keb("甲", "", "")}, Reading: []REle{reb("かな", IrregularKana), reb("カナ", "")}, Sense: uk},
+			"カナ", []string{"かな", "甲【カナ】", "甲【かな】"}},
+		{"uk on a later sense",
+			Entry{Kanji: []KEle{keb("甲", "", "")}, Reading: []REle{reb("かな", "")},
+				Sense: append([]Sense{{Gloss: []string{"y"}}}, uk...)},
+			"甲【かな】", []string{}},
+		{"uk without kanji",
+			Entry{Reading: []REle{reb("かな", "")}, Sense: uk},
+			"かな", []string{}},
+		{"empty", Entry{}, "", []string{}},
+	}
+	for _, test := range tests {
+		head, alternates := headwordStrings(&test.entry)
+		if head != test.head || !reflect.DeepEqual(alternates, test.alternates) {
+			t.Errorf("%s: Headword() = %s, %q; want %s, %q", test.name, head, alternates, test.head, test.alternates)
+		}
+	}
+}
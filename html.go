@@ -0,0 +1,89 @@
+package jmdict
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Separator between the components of an xref or ant element
+const xrefSeparator = "・"
+
+// Writes an HTML rendering of e to b. Tags are shown by their entity
+// code with the description as a tooltip, and cross-references link
+// to their target headword with the given link prefix, for instance
+// "bword://" for StarDict.
+func writeEntryHTML(b *strings.Builder, e *Entry, link string) {
+	b.WriteString(`<div class="jmdict">`)
+	b.WriteString(`<p class="forms">`)
+	for i, k := range e.Kanji {
+		if i > 0 {
+			b.WriteString("、")
+		}
+		fmt.Fprintf(b, `<span class="keb">%s</span>`, html.EscapeString(string(k.Phrase)))
+	}
+	if len(e.Kanji) > 0 {
+		b.WriteString("【")
+	}
+	for i, r := range e.Reading {
+		if i > 0 {
+			b.WriteString("、")
+		}
+		fmt.Fprintf(b, `<span class="reb">%s</span>`, html.EscapeString(string(r.Phrase)))
+	}
+	if len(e.Kanji) > 0 {
+		b.WriteString("】")
+	}
+	b.WriteString("</p><ol>")
+
+	for i := range e.Sense {
+		s := &e.Sense[i]
+		b.WriteString("<li>")
+		for _, p := range s.Position {
+			writeTagHTML(b, string(p), DescribePosition(p))
+		}
+		for _, m := range s.Misc {
+			writeTagHTML(b, string(m), describeMisc(m))
+		}
+		for _, f := range s.Field {
+			writeTagHTML(b, string(f), DescribeField(f))
+		}
+		for _, d := range s.Dialect {
+			writeTagHTML(b, string(d), DescribeDialect(d))
+		}
+		restrict := append(append([]string(nil), s.KanjiRestrict...), s.ReadingRestrict...)
+		if len(restrict) > 0 {
+			fmt.Fprintf(b, `<span class="restrict">(%s only)</span> `,
+				html.EscapeString(strings.Join(restrict, ", ")))
+		}
+		b.WriteString(html.EscapeString(strings.Join(s.Gloss, "; ")))
+		for _, info := range s.Info {
+			fmt.Fprintf(b, ` <span class="info">(%s)</span>`, html.EscapeString(info))
+		}
+		writeRefsHTML(b, "xref", "See also", s.Xref, link)
+		writeRefsHTML(b, "ant", "Antonym", s.Antonym, link)
+		b.WriteString("</li>")
+	}
+	b.WriteString("</ol></div>")
+}
+
+func writeTagHTML(b *strings.Builder, code, desc string) {
+	fmt.Fprintf(b, `<span class="tag" title="%s">%s</span> `,
+		html.EscapeString(desc), html.EscapeString(code))
+}
+
+func writeRefsHTML(b *strings.Builder, class, label string, refs []string, link string) {
+	if len(refs) == 0 {
+		return
+	}
+	fmt.Fprintf(b, ` <span class="%s">%s: `, class, label)
+	for i, ref := range refs {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		target := strings.SplitN(ref, xrefSeparator, 2)[0]
+		fmt.Fprintf(b, `<a href="%s%s">%s</a>`, link,
+			html.EscapeString(target), html.EscapeString(ref))
+	}
+	b.WriteString("</span>")
+}
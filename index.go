@@ -0,0 +1,215 @@
+package jmdict
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// An Index answers lookups against a dictionary held in memory. Results
+// are ordered from most to least common, as by Ranker.Sort, and hold
+// each matching entry once.
+type Index struct {
+	dict   JMDict
+	scores []int
+	bySeq  map[EntSeq]int
+	keys   []indexKey // sorted by key
+	words  map[string][]int
+	kanji  map[rune][]int
+}
+
+type indexKey struct {
+	key   string
+	entry int
+}
+
+// NewIndex indexes the entries of dict, which must not be modified while
+// the index is in use. Kebs and rebs are indexed with katakana folded to
+// hiragana, and glosses by their lower case words.
+func NewIndex(dict JMDict) *Index {
+	var ranker Ranker
+	x := &Index{
+		dict:   dict,
+		scores: make([]int, len(dict.Entries)),
+		bySeq:  make(map[EntSeq]int, len(dict.Entries)),
+		words:  make(map[string][]int),
+		kanji:  make(map[rune][]int),
+	}
+	for i := range dict.Entries {
+		e := &dict.Entries[i]
+		x.scores[i] = ranker.EntryScore(e)
+		x.bySeq[e.Id] = i
+		for _, k := range e.Kanji {
+			x.keys = append(x.keys, indexKey{foldKana(string(k.Phrase)), i})
+			for _, c := range string(k.Phrase) {
+				if !unicode.Is(unicode.Han, c) {
+					continue
+				}
+				if list := x.kanji[c]; len(list) == 0 || list[len(list)-1] != i {
+					x.kanji[c] = append(list, i)
+				}
+			}
+		}
+		for _, r := range e.Reading {
+			x.keys = append(x.keys, indexKey{foldKana(string(r.Phrase)), i})
+		}
+		for _, s := range e.Sense {
+			for _, g := range s.Gloss {
+				for _, w := range glossWords(g) {
+					if list := x.words[w]; len(list) == 0 || list[len(list)-1] != i {
+						x.words[w] = append(list, i)
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(x.keys, func(i, j int) bool {
+		if x.keys[i].key != x.keys[j].key {
+			return x.keys[i].key < x.keys[j].key
+		}
+		return x.keys[i].entry < x.keys[j].entry
+	})
+	return x
+}
+
+// Dict returns the indexed dictionary.
+func (x *Index) Dict() JMDict {
+	return x.dict
+}
+
+// Get returns the entry with the given sequence number.
+func (x *Index) Get(seq EntSeq) (*Entry, bool) {
+	i, ok := x.bySeq[seq]
+	if !ok {
+		return nil, false
+	}
+	return &x.dict.Entries[i], true
+}
+
+// Exact returns the entries with a keb or reb equal to word. Katakana
+// and hiragana match each other.
+func (x *Index) Exact(word string) []*Entry {
+	word = foldKana(word)
+	lo := sort.Search(len(x.keys), func(i int) bool { return x.keys[i].key >= word })
+	var found []int
+	for i := lo; i < len(x.keys) && x.keys[i].key == word; i++ {
+		found = append(found, x.keys[i].entry)
+	}
+	return x.results(found)
+}
+
+// Prefix returns the entries with a keb or reb starting with prefix.
+func (x *Index) Prefix(prefix string) []*Entry {
+	prefix = foldKana(prefix)
+	lo := sort.Search(len(x.keys), func(i int) bool { return x.keys[i].key >= prefix })
+	var found []int
+	for i := lo; i < len(x.keys) && strings.HasPrefix(x.keys[i].key, prefix); i++ {
+		found = append(found, x.keys[i].entry)
+	}
+	return x.results(found)
+}
+
+// Gloss returns the entries with glosses containing every word of the
+// query, ignoring case and punctuation. The words need not all be in
+// the same gloss.
+func (x *Index) Gloss(query string) []*Entry {
+	words := glossWords(query)
+	if len(words) == 0 {
+		return nil
+	}
+	found := x.words[words[0]]
+	for _, w := range words[1:] {
+		found = intersectSorted(found, x.words[w])
+	}
+	return x.results(found)
+}
+
+// Romaji returns the entries with a keb or reb equal to the romaji query
+// once converted with RomajiToKana.
+func (x *Index) Romaji(query string) []*Entry {
+	return x.Exact(RomajiToKana(query))
+}
+
+// Kanji returns the entries with a keb containing the kanji c.
+func (x *Index) Kanji(c rune) []*Entry {
+	return x.results(x.kanji[c])
+}
+
+// Removes duplicates from a list of entry indexes, and returns the
+// entries from most to least common, then in dictionary order.
+func (x *Index) results(found []int) []*Entry {
+	unique := make([]int, 0, len(found))
+	seen := make(map[int]bool, len(found))
+	for _, i := range found {
+		if !seen[i] {
+			seen[i] = true
+			unique = append(unique, i)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		a, b := unique[i], unique[j]
+		if x.scores[a] != x.scores[b] {
+			return x.scores[a] > x.scores[b]
+		}
+		return a < b
+	})
+	entries := make([]*Entry, len(unique))
+	for i, e := range unique {
+		entries[i] = &x.dict.Entries[e]
+	}
+	return entries
+}
+
+// Folds katakana to hiragana.
+func foldKana(s string) string {
+	return strings.Map(toHiragana, s)
+}
+
+// Splits a gloss into lower case words, dropping punctuation.
+func glossWords(gloss string) []string {
+	return strings.FieldsFunc(strings.ToLower(gloss), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	})
+}
+
+func intersectSorted(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// A Match is an entry found for an inflected word, with the
+// deinflection which led to it.
+type Match struct {
+	Entry *Entry
+	Deinflection
+}
+
+// Inflected returns the entries with a keb or reb equal to word or to
+// one of its dictionary forms, as given by Deinflect, whose
+// part-of-speech agrees with the deinflection. Entries matching word
+// itself come first, then those needing fewer inflections to be undone.
+func (x *Index) Inflected(word string) []Match {
+	var matches []Match
+	seen := make(map[EntSeq]bool)
+	for _, d := range Deinflect(word) {
+		for _, e := range x.Exact(d.Word) {
+			if !seen[e.Id] && d.Matches(e) {
+				seen[e.Id] = true
+				matches = append(matches, Match{e, d})
+			}
+		}
+	}
+	return matches
+}
@@ -0,0 +1,233 @@
+package jmdict
+
+import (
+	"encoding/xml"
+	"io"
+	"reflect"
+	"unsafe"
+)
+
+// An Interner shares the storage of equal strings. The zero value is
+// ready to use. An Interner is not safe for concurrent use.
+type Interner struct {
+	strings map[string]string
+}
+
+// String returns a string equal to s, sharing storage with the first
+// such string passed to the Interner.
+func (in *Interner) String(s string) string {
+	if s == "" {
+		return ""
+	}
+	if interned, ok := in.strings[s]; ok {
+		return interned
+	}
+	if in.strings == nil {
+		in.strings = make(map[string]string)
+	}
+	in.strings[s] = s
+	return s
+}
+
+// Len returns the number of distinct strings held.
+func (in *Interner) Len() int {
+	return len(in.strings)
+}
+
+func (in *Interner) name(n xml.Name) xml.Name {
+	return xml.Name{Space: in.String(n.Space), Local: in.String(n.Local)}
+}
+
+func (in *Interner) strs(list []string) {
+	for i := range list {
+		list[i] = in.String(list[i])
+	}
+}
+
+// Compact reduces the memory held by the entry by interning its
+// enumerated values with in: the entity codes, priorities, gloss and
+// source languages, the tags of links, bibliography and audit records,
+// and the XML names, which the decoder allocates afresh for every
+// element. Slices the decoder grew past their length are reallocated to
+// fit. The compacted entry is equal to the original.
+//
+// The XMLName fields themselves cannot be dropped: they are part of the
+// exported types, which Read, the binary format and every caller share,
+// so an entry keeps their 32 bytes per element and only their strings
+// are shared.
+func (e *Entry) Compact(in *Interner) {
+	e.XMLName = in.name(e.XMLName)
+	e.Kanji = fit(e.Kanji)
+	e.Reading = fit(e.Reading)
+	e.Sense = fit(e.Sense)
+	for i := range e.Kanji {
+		k := &e.Kanji[i]
+		k.XMLName = in.name(k.XMLName)
+		k.Info = fit(k.Info)
+		k.Priority = fit(k.Priority)
+		for j := range k.Info {
+			k.Info[j] = Orthography(in.String(string(k.Info[j])))
+		}
+		for j := range k.Priority {
+			k.Priority[j].XMLName = in.name(k.Priority[j].XMLName)
+			k.Priority[j].Priority = k.Priority[j].Priority.intern(in)
+		}
+	}
+	for i := range e.Reading {
+		r := &e.Reading[i]
+		r.XMLName = in.name(r.XMLName)
+		r.Restrict = fit(r.Restrict)
+		r.Orthography = fit(r.Orthography)
+		r.Priority = fit(r.Priority)
+		for j := range r.Orthography {
+			r.Orthography[j] = Orthography(in.String(string(r.Orthography[j])))
+		}
+		for j := range r.Priority {
+			r.Priority[j].XMLName = in.name(r.Priority[j].XMLName)
+			r.Priority[j].Priority = r.Priority[j].Priority.intern(in)
+		}
+	}
+
+	info := &e.Info
+	info.XMLName = in.name(info.XMLName)
+	info.Links = fit(info.Links)
+	info.Bibl = fit(info.Bibl)
+	info.Etym = fit(info.Etym)
+	info.Audit = fit(info.Audit)
+	for i := range info.Links {
+		info.Links[i].XMLName = in.name(info.Links[i].XMLName)
+		info.Links[i].LinkTag = in.String(info.Links[i].LinkTag)
+	}
+	for i := range info.Bibl {
+		info.Bibl[i].XMLName = in.name(info.Bibl[i].XMLName)
+		info.Bibl[i].BibTag = in.String(info.Bibl[i].BibTag)
+	}
+	for i := range info.Audit {
+		info.Audit[i].XMLName = in.name(info.Audit[i].XMLName)
+		info.Audit[i].UpdDate = in.String(info.Audit[i].UpdDate)
+		info.Audit[i].UpdDetl = in.String(info.Audit[i].UpdDetl)
+	}
+
+	for i := range e.Sense {
+		s := &e.Sense[i]
+		s.XMLName = in.name(s.XMLName)
+		s.KanjiRestrict = fit(s.KanjiRestrict)
+		s.ReadingRestrict = fit(s.ReadingRestrict)
+		s.Position = fit(s.Position)
+		s.Xref = fit(s.Xref)
+		s.Antonym = fit(s.Antonym)
+		s.Field = fit(s.Field)
+		s.Misc = fit(s.Misc)
+		s.Info = fit(s.Info)
+		s.LSource = fit(s.LSource)
+		s.Dialect = fit(s.Dialect)
+		s.Gloss = fit(s.Gloss)
+		s.Example = fit(s.Example)
+		s.GlossLang = fit(s.GlossLang)
+		for j := range s.Position {
+			s.Position[j] = Position(in.String(string(s.Position[j])))
+		}
+		for j := range s.Field {
+			s.Field[j] = Field(in.String(string(s.Field[j])))
+		}
+		for j := range s.Misc {
+			s.Misc[j] = Misc(in.String(string(s.Misc[j])))
+		}
+		for j := range s.Dialect {
+			s.Dialect[j] = Dialect(in.String(string(s.Dialect[j])))
+		}
+		in.strs(s.GlossLang)
+		for j := range s.LSource {
+			l := &s.LSource[j]
+			l.XMLName = in.name(l.XMLName)
+			l.Lang = in.String(l.Lang)
+			l.Type = in.String(l.Type)
+			l.Wasei = in.String(l.Wasei)
+		}
+	}
+}
+
+// Returns list, reallocated without spare capacity if it has any.
+func fit[T any](list []T) []T {
+	if len(list) == cap(list) {
+		return list
+	}
+	fitted := make([]T, len(list))
+	copy(fitted, list)
+	return fitted
+}
+
+func (p Priority) intern(in *Interner) Priority {
+	p.Raw = in.String(p.Raw)
+	p.Code = PriorityCode(in.String(string(p.Code)))
+	return p
+}
+
+// Compact compacts every entry of the dictionary, see Entry.Compact.
+func (d *JMDict) Compact(in *Interner) {
+	d.XMLName = in.name(d.XMLName)
+	d.Entries = fit(d.Entries)
+	for i := range d.Entries {
+		d.Entries[i].Compact(in)
+	}
+}
+
+// ReadCompact reads a dictionary like Read, compacting each entry as
+// soon as it is decoded, so that the duplicate strings of the whole
+// dictionary are never resident at once.
+func ReadCompact(r io.Reader) (JMDict, error) {
+	return NewReader(r, WithInterner(&Interner{})).Read()
+}
+
+// MemoryUsage estimates the memory held by a dictionary.
+type MemoryUsage struct {
+	Structs     int64 // bytes of structs, slices and pointers
+	Strings     int64 // bytes of distinct string data
+	SharedBytes int64 // string bytes saved by sharing storage
+}
+
+// Total returns the estimated number of bytes held.
+func (u MemoryUsage) Total() int64 {
+	return u.Structs + u.Strings
+}
+
+// MemoryUsage estimates the memory held by the dictionary's entries. It
+// counts the backing arrays of slices at their capacity and the data of
+// strings which share storage once. Allocator overhead is not counted.
+func (d *JMDict) MemoryUsage() MemoryUsage {
+	var u MemoryUsage
+	seen := make(map[*byte]bool)
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.String:
+			s := v.String()
+			if s == "" {
+				return
+			}
+			data := unsafe.StringData(s)
+			if seen[data] {
+				u.SharedBytes += int64(len(s))
+				return
+			}
+			seen[data] = true
+			u.Strings += int64(len(s))
+		case reflect.Slice:
+			u.Structs += int64(v.Cap()) * int64(v.Type().Elem().Size())
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Ptr:
+			if !v.IsNil() {
+				u.Structs += int64(v.Type().Elem().Size())
+				walk(v.Elem())
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				walk(v.Field(i))
+			}
+		}
+	}
+	walk(reflect.ValueOf(d).Elem())
+	return u
+}
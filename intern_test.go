@@ -0,0 +1,78 @@
+package jmdict
+
+import (
+	"bytes"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	dict := readSample(t)
+	compact := readSample(t)
+	var in Interner
+	compact.Compact(&in)
+	if !reflect.DeepEqual(compact, dict) {
+		t.Error("compacted dictionary differs from the original")
+	}
+
+	before, after := dict.MemoryUsage(), compact.MemoryUsage()
+	if after.Strings >= before.Strings || after.SharedBytes <= before.SharedBytes {
+		t.Errorf("memory usage %+v after compacting, %+v before", after, before)
+	}
+	if after.Structs > before.Structs {
+		t.Errorf("compacting grew struct bytes from %d to %d", before.Structs, after.Structs)
+	}
+
+	// Spare capacity left by the decoder is released.
+	e := sampleEntry(t, compact, 1358280)
+	e.Sense[0].Gloss = append(make([]string, 0, 16), e.Sense[0].Gloss...)
+	e.Compact(&in)
+	if n := cap(e.Sense[0].Gloss); n != 2 {
+		t.Errorf("compacted glosses have capacity %d, want 2", n)
+	}
+}
+
+// Returns the sample dictionary with its entries repeated n times.
+func repeatedSample(t testing.TB, n int) []byte {
+	s := sampleText(t)
+	start, end := strings.Index(s, "<entry>"), strings.LastIndex(s, "</JMdict>")
+	return []byte(s[:start] + strings.Repeat(s[start:end], n) + s[end:])
+}
+
+// Reports the heap held by the dictionaries read, per entry.
+func benchmarkRead(b *testing.B, read func([]byte) (JMDict, error)) {
+	data := repeatedSample(b, 500)
+	dicts := make([]JMDict, b.N)
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range dicts {
+		dict, err := read(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		dicts[i] = dict
+	}
+	b.StopTimer()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	entries := b.N * len(dicts[0].Entries)
+	b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/float64(entries), "heap-B/entry")
+	runtime.KeepAlive(dicts)
+}
+
+func BenchmarkRead(b *testing.B) {
+	benchmarkRead(b, func(data []byte) (JMDict, error) {
+		return Read(bytes.NewReader(data))
+	})
+}
+
+func BenchmarkReadCompact(b *testing.B) {
+	benchmarkRead(b, func(data []byte) (JMDict, error) {
+		return ReadCompact(bytes.NewReader(data))
+	})
+}
@@ -411,6 +411,10 @@ type Sense struct {
 	Dialect []Dialect `xml:"dial"`
 	Gloss   []string  `xml:"gloss"`
 	Example []string  `xml:"example"`
+
+	// The xml:lang attribute of each gloss, in the same order as Gloss.
+	// Glosses without the attribute are English, "eng".
+	GlossLang []string `xml:"-"`
 }
 
 type LSource struct {
@@ -609,26 +613,33 @@ func describeMisc(misc Misc) string {
 }
 
 func Read(r io.Reader) (JMDict, error) {
-	var dict JMDict
-	decoder := xml.NewDecoder(r)
+	return NewReader(r).Read()
+}
 
-	decoder.Entity = make(map[string]string)
+// Entity table mapping each entity code to itself, so that tags decode
+// to their codes rather than their descriptions.
+var entities map[string]string = make(map[string]string)
+
+func init() {
 	for k := range positionDescriptions {
-		decoder.Entity[string(k)] = string(k)
+		entities[string(k)] = string(k)
 	}
 	for k := range fieldDescriptions {
-		decoder.Entity[string(k)] = string(k)
+		entities[string(k)] = string(k)
 	}
 	for k := range miscDescriptions {
-		decoder.Entity[string(k)] = string(k)
+		entities[string(k)] = string(k)
 	}
 	for k := range orthographyDescriptions {
-		decoder.Entity[string(k)] = string(k)
+		entities[string(k)] = string(k)
 	}
 	for k := range dialectDescriptions {
-		decoder.Entity[string(k)] = string(k)
+		entities[string(k)] = string(k)
 	}
+}
 
-	err := decoder.Decode(&dict)
-	return dict, err
+func newDecoder(r io.Reader) *xml.Decoder {
+	decoder := xml.NewDecoder(r)
+	decoder.Entity = entities
+	return decoder
 }
@@ -0,0 +1,66 @@
+package jmdict
+
+import (
+	"os"
+	"testing"
+)
+
+// Reads testdata/sample.xml, a small dictionary in the form of JMdict.
+func readSample(t testing.TB) JMDict {
+	t.Helper()
+	f, err := os.Open("testdata/sample.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	dict, err := Read(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dict
+}
+
+// Returns the text of testdata/sample.xml.
+func sampleText(t testing.TB) string {
+	t.Helper()
+	data, err := os.ReadFile("testdata/sample.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// Returns the entry of dict with the given sequence number.
+func sampleEntry(t testing.TB, dict JMDict, seq EntSeq) *Entry {
+	t.Helper()
+	for i := range dict.Entries {
+		if dict.Entries[i].Id == seq {
+			return &dict.Entries[i]
+		}
+	}
+	t.Fatalf("no entry %d in sample", seq)
+	return nil
+}
+
+func TestRead(t *testing.T) {
+	dict := readSample(t)
+	if len(dict.Entries) != 8 {
+		t.Fatalf("read %d entries, want 8", len(dict.Entries))
+	}
+
+	e := sampleEntry(t, dict, 1358280)
+	if len(e.Kanji) != 2 || e.Kanji[1].Phrase != "喰べる" || e.Kanji[1].Info[0] != IrregularKanji {
+		t.Errorf("k_ele = %+v", e.Kanji)
+	}
+	if len(e.Sense) != 2 || len(e.Sense[0].Position) != 2 || e.Sense[0].Position[0] != Verb1 {
+		t.Errorf("sense = %+v", e.Sense)
+	}
+	if got := e.Sense[0].GlossLang; len(got) != 2 || got[0] != "eng" || got[1] != "ger" {
+		t.Errorf("gloss languages = %q, want [eng ger]", got)
+	}
+
+	e = sampleEntry(t, dict, 1080180)
+	if e.Reading[0].ImproperReading != nil || e.Reading[1].ImproperReading == nil {
+		t.Errorf("re_nokanji not decoded: %+v", e.Reading)
+	}
+}
@@ -0,0 +1,276 @@
+package jmdictgrpc
+
+import (
+	"github.com/0xfaded/jmdict"
+	"github.com/0xfaded/jmdict/jmdictgrpc/jmdictpb"
+)
+
+// The entity codes of an enum in jmdictpb, in order of enum value from
+// one. Value zero, UNSPECIFIED, stands for any code not listed.
+type enumCodes map[string]int32
+
+func newEnumCodes(codes ...string) enumCodes {
+	t := make(enumCodes, len(codes))
+	for i, code := range codes {
+		t[code] = int32(i + 1)
+	}
+	return t
+}
+
+// Entity codes must be listed in the order of jmdict.proto.
+var positionCodes = newEnumCodes(
+	"adj-i",     // POSITION_ADJ_I
+	"adj-ku",    // POSITION_ADJ_KU
+	"adj-na",    // POSITION_ADJ_NA
+	"adj-nari",  // POSITION_ADJ_NARI
+	"adj-no",    // POSITION_ADJ_NO
+	"adj-pn",    // POSITION_ADJ_PRE_NOUN
+	"adj-shiku", // POSITION_ADJ_SHIKU
+	"adj-t",     // POSITION_ADJ_TARU
+	"adj-f",     // POSITION_ADJ_FUNCTIONAL
+	"adv",       // POSITION_ADV
+	"adv-to",    // POSITION_ADV_TO
+	"aux",       // POSITION_AUX
+	"aux-adj",   // POSITION_AUX_ADJ
+	"aux-v",     // POSITION_AUX_VERB
+	"conj",      // POSITION_CONJ
+	"ctr",       // POSITION_COUNTER
+	"exp",       // POSITION_EXPRESSION
+	"int",       // POSITION_INTERJECTION
+	"n",         // POSITION_NOUN
+	"n-adv",     // POSITION_NOUN_ADV
+	"n-pr",      // POSITION_NOUN_PROPPER
+	"n-pref",    // POSITION_NOUN_PREFIX
+	"n-suf",     // POSITION_NOUN_SUFFIX
+	"n-t",       // POSITION_NOUN_TEMPORAL
+	"num",       // POSITION_NUMERIC
+	"pn",        // POSITION_PRONOUN
+	"pref",      // POSITION_PREFIX
+	"prt",       // POSITION_PARTICLE
+	"suf",       // POSITION_SUFFIX
+	"v1",        // POSITION_VERB1
+	"v2a-s",     // POSITION_VERB2AS
+	"v2b-k",     // POSITION_VERB2BK
+	"v2d-s",     // POSITION_VERB2DS
+	"v2g-k",     // POSITION_VERB2GK
+	"v2g-s",     // POSITION_VERB2GS
+	"v2h-k",     // POSITION_VERB2HK
+	"v2h-s",     // POSITION_VERB2HS
+	"v2k-k",     // POSITION_VERB2KK
+	"v2k-s",     // POSITION_VERB2KS
+	"v2m-s",     // POSITION_VERB2MS
+	"v2n-s",     // POSITION_VERB2NS
+	"v2r-k",     // POSITION_VERB2RK
+	"v2r-s",     // POSITION_VERB2RS
+	"v2s-s",     // POSITION_VERB2SS
+	"v2t-k",     // POSITION_VERB2TK
+	"v2t-s",     // POSITION_VERB2TS
+	"v2w-s",     // POSITION_VERB2WS
+	"v2y-k",     // POSITION_VERB2YK
+	"v2y-s",     // POSITION_VERB2YS
+	"v2z-s",     // POSITION_VERB2ZS
+	"v4b",       // POSITION_VERB4B
+	"v4h",       // POSITION_VERB4H
+	"v4k",       // POSITION_VERB4K
+	"v4r",       // POSITION_VERB4R
+	"v4s",       // POSITION_VERB4S
+	"v4t",       // POSITION_VERB4T
+	"v5aru",     // POSITION_VERB5ARU
+	"v5b",       // POSITION_VERB5B
+	"v5g",       // POSITION_VERB5G
+	"v5k",       // POSITION_VERB5K
+	"v5k-s",     // POSITION_VERB5KS
+	"v5m",       // POSITION_VERB5M
+	"v5n",       // POSITION_VERB5N
+	"v5r",       // POSITION_VERB5R
+	"v5r-i",     // POSITION_VERB5RI
+	"v5s",       // POSITION_VERB5S
+	"v5t",       // POSITION_VERB5T
+	"v5u",       // POSITION_VERB5U
+	"v5u-s",     // POSITION_VERB5US
+	"vi",        // POSITION_VERB_INTRANSITIVE
+	"vk",        // POSITION_VERB_KURU
+	"vn",        // POSITION_VERB_NU
+	"vr",        // POSITION_VERB_RU
+	"vs",        // POSITION_VERB_SURU
+	"vs-c",      // POSITION_VERB_SU
+	"vs-i",      // POSITION_VERB_IRREGULAR_SURU
+	"vs-s",      // POSITION_VERB_SURU_SPECIAL
+	"vt",        // POSITION_VERB_TRANSITIVE
+	"vz",        // POSITION_VERB_ZURU
+)
+
+var fieldCodes = newEnumCodes(
+	"anat",    // FIELD_ANATOMICAL
+	"archit",  // FIELD_ARCHITECTURE
+	"astron",  // FIELD_ASTRONOMY
+	"baseb",   // FIELD_BASEBALL
+	"biol",    // FIELD_BIOLOGY
+	"bot",     // FIELD_BOTANY
+	"Buddh",   // FIELD_BUDDHIST
+	"bus",     // FIELD_BUSINESS
+	"chem",    // FIELD_CHEMISTRY
+	"comp",    // FIELD_COMPUTER
+	"econ",    // FIELD_ECONOMICS
+	"engr",    // FIELD_ENGINEERING
+	"finc",    // FIELD_FINANCE
+	"food",    // FIELD_FOOD
+	"geol",    // FIELD_GEOLOGY
+	"geom",    // FIELD_GEOMETRY
+	"law",     // FIELD_LAW
+	"ling",    // FIELD_LINGUISTICS
+	"MA",      // FIELD_MARTIAL
+	"math",    // FIELD_MATHEMATICS
+	"med",     // FIELD_MEDICINE
+	"mil",     // FIELD_MILITARY
+	"music",   // FIELD_MUSIC
+	"physics", // FIELD_PHYSICS
+	"Shinto",  // FIELD_SHINTO
+	"sports",  // FIELD_SPORTS
+	"sumo",    // FIELD_SUMO
+	"zool",    // FIELD_ZOOLOGY
+)
+
+var miscCodes = newEnumCodes(
+	"abbr",    // MISC_ABBREVIATION
+	"arch",    // MISC_ARCHAISM
+	"chn",     // MISC_CHILD_LANGUAGE
+	"col",     // MISC_COLLOQUIALISM
+	"derog",   // MISC_DEROGATORY
+	"fam",     // MISC_FAMILIAR
+	"fem",     // MISC_FEMALE_LANGUAGE
+	"hon",     // MISC_HONORIFIC
+	"hum",     // MISC_HUMBLE
+	"id",      // MISC_IDIOMATIC
+	"joc",     // MISC_JOCULAR
+	"male",    // MISC_MALE_LANGUAGE
+	"m-sl",    // MISC_MANGA
+	"obs",     // MISC_OBSOLETE
+	"obsc",    // MISC_OBSCURE
+	"on-mim",  // MISC_ONOMATOPOEIC
+	"poet",    // MISC_POETICAL
+	"pol",     // MISC_POLITE
+	"proverb", // MISC_PROVERB
+	"rare",    // MISC_RARE
+	"sens",    // MISC_SENSITIVE
+	"sl",      // MISC_SLANG
+	"uk",      // MISC_KANA_ALONE
+	"vulg",    // MISC_VULGAR
+	"X",       // MISC_X_RATED
+)
+
+var dialectCodes = newEnumCodes(
+	"hob",  // DIALECT_HOKKAIDO_BEN
+	"ksb",  // DIALECT_KANSAI_BEN
+	"ktb",  // DIALECT_KANTOU_BEN
+	"kyb",  // DIALECT_KYOTO_BEN
+	"kyu",  // DIALECT_KYUUSHUU_BEN
+	"nab",  // DIALECT_NAGANO_BEN
+	"osb",  // DIALECT_OSAKA_BEN
+	"rkb",  // DIALECT_RYUUKYUU_BEN
+	"thb",  // DIALECT_TOUHOKU_BEN
+	"tsb",  // DIALECT_TOSA_BEN
+	"tsug", // DIALECT_TSUGARU_BEN
+)
+
+var orthographyCodes = newEnumCodes(
+	"ateji", // ORTHOGRAPHY_ATEJI
+	"gikun", // ORTHOGRAPHY_GIKUN
+	"iK",    // ORTHOGRAPHY_IRREGULAR_KANJI
+	"ik",    // ORTHOGRAPHY_IRREGULAR_KANA
+	"io",    // ORTHOGRAPHY_IRREGULAR_OKURIGANA
+	"oK",    // ORTHOGRAPHY_OUTDATED_KANJI
+	"ok",    // ORTHOGRAPHY_OUTDATED_KANA
+	"oik",   // ORTHOGRAPHY_OUTDATED_OR_IRREGULAR_KANA
+	"uK",    // ORTHOGRAPHY_KANJI_ALONE
+)
+
+// EntryProto returns the protocol buffer form of an entry. Every entity
+// code is given in the _code field which follows its enum field, so no
+// information is lost when a code has no enum value and is given as the
+// UNSPECIFIED value.
+func EntryProto(e *jmdict.Entry) *jmdictpb.Entry {
+	p := &jmdictpb.Entry{
+		EntSeq: uint64(e.Id),
+		KEle:   make([]*jmdictpb.KEle, len(e.Kanji)),
+		REle:   make([]*jmdictpb.REle, len(e.Reading)),
+		Sense:  make([]*jmdictpb.Sense, len(e.Sense)),
+	}
+	for i, k := range e.Kanji {
+		pk := &jmdictpb.KEle{Keb: string(k.Phrase)}
+		for _, o := range k.Info {
+			pk.KeInf = append(pk.KeInf, jmdictpb.Orthography(orthographyCodes[string(o)]))
+			pk.KeInfCode = append(pk.KeInfCode, string(o))
+		}
+		for _, pri := range k.Priority {
+			pk.KePri = append(pk.KePri, pri.Raw)
+		}
+		p.KEle[i] = pk
+	}
+	for i, r := range e.Reading {
+		pr := &jmdictpb.REle{Reb: string(r.Phrase), ReNokanji: r.ImproperReading != nil}
+		for _, restr := range r.Restrict {
+			pr.ReRestr = append(pr.ReRestr, string(restr))
+		}
+		for _, o := range r.Orthography {
+			pr.ReInf = append(pr.ReInf, jmdictpb.Orthography(orthographyCodes[string(o)]))
+			pr.ReInfCode = append(pr.ReInfCode, string(o))
+		}
+		for _, pri := range r.Priority {
+			pr.RePri = append(pr.RePri, pri.Raw)
+		}
+		p.REle[i] = pr
+	}
+
+	info := e.Info
+	if len(info.Links)+len(info.Bibl)+len(info.Etym)+len(info.Audit) > 0 {
+		p.Info = &jmdictpb.Info{Etym: info.Etym}
+		for _, l := range info.Links {
+			p.Info.Links = append(p.Info.Links,
+				&jmdictpb.Links{LinkTag: l.LinkTag, LinkDesc: l.LinkDesc, LinkUri: l.LinkUri})
+		}
+		for _, b := range info.Bibl {
+			p.Info.Bibl = append(p.Info.Bibl, &jmdictpb.Bibl{BibTag: b.BibTag, BibTxt: b.BibTxt})
+		}
+		for _, a := range info.Audit {
+			p.Info.Audit = append(p.Info.Audit, &jmdictpb.Audit{UpdDate: a.UpdDate, UpdDetl: a.UpdDetl})
+		}
+	}
+
+	for i, s := range e.Sense {
+		ps := &jmdictpb.Sense{
+			Stagk:   s.KanjiRestrict,
+			Stagr:   s.ReadingRestrict,
+			Xref:    s.Xref,
+			Ant:     s.Antonym,
+			SInf:    s.Info,
+			Gloss:   make([]*jmdictpb.Gloss, len(s.Gloss)),
+			Example: s.Example,
+		}
+		for _, pos := range s.Position {
+			ps.Pos = append(ps.Pos, jmdictpb.Position(positionCodes[string(pos)]))
+			ps.PosCode = append(ps.PosCode, string(pos))
+		}
+		for _, f := range s.Field {
+			ps.Field = append(ps.Field, jmdictpb.Field(fieldCodes[string(f)]))
+			ps.FieldCode = append(ps.FieldCode, string(f))
+		}
+		for _, m := range s.Misc {
+			ps.Misc = append(ps.Misc, jmdictpb.Misc(miscCodes[string(m)]))
+			ps.MiscCode = append(ps.MiscCode, string(m))
+		}
+		for _, d := range s.Dialect {
+			ps.Dial = append(ps.Dial, jmdictpb.Dialect(dialectCodes[string(d)]))
+			ps.DialCode = append(ps.DialCode, string(d))
+		}
+		for _, l := range s.LSource {
+			ps.Lsource = append(ps.Lsource,
+				&jmdictpb.LSource{Lang: l.Lang, LsType: l.Type, LsWasei: l.Wasei, Text: l.Source})
+		}
+		for g, text := range s.Gloss {
+			ps.Gloss[g] = &jmdictpb.Gloss{Lang: s.GlossLanguage(g), Text: text}
+		}
+		p.Sense[i] = ps
+	}
+	return p
+}
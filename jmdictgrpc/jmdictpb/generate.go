@@ -0,0 +1,6 @@
+// Package jmdictpb holds the protocol buffer messages and gRPC stubs of
+// the jmdict.v1 DictionaryService, generated from jmdict.proto with
+// protoc-gen-go and protoc-gen-go-grpc.
+package jmdictpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative jmdict.proto
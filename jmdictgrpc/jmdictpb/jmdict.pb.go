@@ -0,0 +1,2324 @@
+// Protocol buffer definitions for dictionary lookups against JMdict.
+//
+// The messages mirror the types of package jmdict, and their fields are
+// named after the DTD elements, as in the JSON encoding. Entity codes are
+// given both as enums and, in the _code field which follows each enum
+// field, as the codes themselves. A code without an enum value, for
+// instance one added to JMdict since these enums were written, has the
+// UNSPECIFIED value and is only given in full by the _code field.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: jmdict.proto
+
+package jmdictpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SearchMode int32
+
+const (
+	SearchMode_SEARCH_MODE_UNSPECIFIED SearchMode = 0 // the same as SEARCH_MODE_EXACT
+	SearchMode_SEARCH_MODE_EXACT       SearchMode = 1 // keb or reb equal to the query
+	SearchMode_SEARCH_MODE_PREFIX      SearchMode = 2 // keb or reb starting with the query
+	SearchMode_SEARCH_MODE_GLOSS       SearchMode = 3 // glosses containing every word of the query
+	SearchMode_SEARCH_MODE_ROMAJI      SearchMode = 4 // keb or reb equal to the query as kana
+	SearchMode_SEARCH_MODE_KANJI       SearchMode = 5 // keb containing the query, a single character
+)
+
+// Enum value maps for SearchMode.
+var (
+	SearchMode_name = map[int32]string{
+		0: "SEARCH_MODE_UNSPECIFIED",
+		1: "SEARCH_MODE_EXACT",
+		2: "SEARCH_MODE_PREFIX",
+		3: "SEARCH_MODE_GLOSS",
+		4: "SEARCH_MODE_ROMAJI",
+		5: "SEARCH_MODE_KANJI",
+	}
+	SearchMode_value = map[string]int32{
+		"SEARCH_MODE_UNSPECIFIED": 0,
+		"SEARCH_MODE_EXACT":       1,
+		"SEARCH_MODE_PREFIX":      2,
+		"SEARCH_MODE_GLOSS":       3,
+		"SEARCH_MODE_ROMAJI":      4,
+		"SEARCH_MODE_KANJI":       5,
+	}
+)
+
+func (x SearchMode) Enum() *SearchMode {
+	p := new(SearchMode)
+	*p = x
+	return p
+}
+
+func (x SearchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_jmdict_proto_enumTypes[0].Descriptor()
+}
+
+func (SearchMode) Type() protoreflect.EnumType {
+	return &file_jmdict_proto_enumTypes[0]
+}
+
+func (x SearchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchMode.Descriptor instead.
+func (SearchMode) EnumDescriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{0}
+}
+
+type Position int32
+
+const (
+	Position_POSITION_UNSPECIFIED         Position = 0
+	Position_POSITION_ADJ_I               Position = 1  // adj-i: adjective (keiyoushi)
+	Position_POSITION_ADJ_KU              Position = 2  // adj-ku: `ku' adjective (archaic)
+	Position_POSITION_ADJ_NA              Position = 3  // adj-na: adjectival nouns or quasi-adjectives (keiyodoshi)
+	Position_POSITION_ADJ_NARI            Position = 4  // adj-nari: archaic/formal form of na-adjective
+	Position_POSITION_ADJ_NO              Position = 5  // adj-no: nouns which may take the genitive case particle `no'
+	Position_POSITION_ADJ_PRE_NOUN        Position = 6  // adj-pn: pre-noun adjectival (rentaishi)
+	Position_POSITION_ADJ_SHIKU           Position = 7  // adj-shiku: `shiku' adjective (archaic)
+	Position_POSITION_ADJ_TARU            Position = 8  // adj-t: `taru' adjective
+	Position_POSITION_ADJ_FUNCTIONAL      Position = 9  // adj-f: noun or verb acting prenominally
+	Position_POSITION_ADV                 Position = 10 // adv: adverb (fukushi)
+	Position_POSITION_ADV_TO              Position = 11 // adv-to: adverb taking the `to' particle
+	Position_POSITION_AUX                 Position = 12 // aux: auxiliary
+	Position_POSITION_AUX_ADJ             Position = 13 // aux-adj: auxiliary adjective
+	Position_POSITION_AUX_VERB            Position = 14 // aux-v: auxiliary verb
+	Position_POSITION_CONJ                Position = 15 // conj: conjunction
+	Position_POSITION_COUNTER             Position = 16 // ctr: counter
+	Position_POSITION_EXPRESSION          Position = 17 // exp: Expressions (phrases, clauses, etc.)
+	Position_POSITION_INTERJECTION        Position = 18 // int: interjection (kandoushi)
+	Position_POSITION_NOUN                Position = 19 // n: noun (common) (futsuumeishi)
+	Position_POSITION_NOUN_ADV            Position = 20 // n-adv: adverbial noun (fukushitekimeishi)
+	Position_POSITION_NOUN_PROPPER        Position = 21 // n-pr: proper noun
+	Position_POSITION_NOUN_PREFIX         Position = 22 // n-pref: noun, used as a prefix
+	Position_POSITION_NOUN_SUFFIX         Position = 23 // n-suf: noun, used as a suffix
+	Position_POSITION_NOUN_TEMPORAL       Position = 24 // n-t: noun (temporal) (jisoumeishi)
+	Position_POSITION_NUMERIC             Position = 25 // num: numeric
+	Position_POSITION_PRONOUN             Position = 26 // pn: pronoun
+	Position_POSITION_PREFIX              Position = 27 // pref: prefix
+	Position_POSITION_PARTICLE            Position = 28 // prt: particle
+	Position_POSITION_SUFFIX              Position = 29 // suf: suffix
+	Position_POSITION_VERB1               Position = 30 // v1: Ichidan verb
+	Position_POSITION_VERB2AS             Position = 31 // v2a-s: Nidan verb with 'u' ending (archaic)
+	Position_POSITION_VERB2BK             Position = 32 // v2b-k: Nidan verb (upper class) with `bu' ending (archaic)
+	Position_POSITION_VERB2DS             Position = 33 // v2d-s: Nidan verb (lower class) with `dzu' ending (archaic)
+	Position_POSITION_VERB2GK             Position = 34 // v2g-k: Nidan verb (upper class) with `gu' ending (archaic)
+	Position_POSITION_VERB2GS             Position = 35 // v2g-s: Nidan verb (lower class) with `gu' ending (archaic)
+	Position_POSITION_VERB2HK             Position = 36 // v2h-k: Nidan verb (upper class) with `hu/fu' ending (archaic)
+	Position_POSITION_VERB2HS             Position = 37 // v2h-s: Nidan verb (lower class) with `hu/fu' ending (archaic)
+	Position_POSITION_VERB2KK             Position = 38 // v2k-k: Nidan verb (upper class) with `ku' ending (archaic)
+	Position_POSITION_VERB2KS             Position = 39 // v2k-s: Nidan verb (lower class) with `ku' ending (archaic)
+	Position_POSITION_VERB2MS             Position = 40 // v2m-s: Nidan verb (lower class) with `mu' ending (archaic)
+	Position_POSITION_VERB2NS             Position = 41 // v2n-s: Nidan verb (lower class) with `nu' ending (archaic)
+	Position_POSITION_VERB2RK             Position = 42 // v2r-k: Nidan verb (upper class) with `ru' ending (archaic)
+	Position_POSITION_VERB2RS             Position = 43 // v2r-s: Nidan verb (lower class) with `ru' ending (archaic)
+	Position_POSITION_VERB2SS             Position = 44 // v2s-s: Nidan verb (lower class) with `su' ending (archaic)
+	Position_POSITION_VERB2TK             Position = 45 // v2t-k: Nidan verb (upper class) with `tsu' ending (archaic)
+	Position_POSITION_VERB2TS             Position = 46 // v2t-s: Nidan verb (lower class) with `tsu' ending (archaic)
+	Position_POSITION_VERB2WS             Position = 47 // v2w-s: Nidan verb (lower class) with `u' ending and `we' conjugation (archaic)
+	Position_POSITION_VERB2YK             Position = 48 // v2y-k: Nidan verb (upper class) with `yu' ending (archaic)
+	Position_POSITION_VERB2YS             Position = 49 // v2y-s: Nidan verb (lower class) with `yu' ending (archaic)
+	Position_POSITION_VERB2ZS             Position = 50 // v2z-s: Nidan verb (lower class) with `zu' ending (archaic)
+	Position_POSITION_VERB4B              Position = 51 // v4b: Yodan verb with `bu' ending (archaic)
+	Position_POSITION_VERB4H              Position = 52 // v4h: Yodan verb with `hu/fu' ending (archaic)
+	Position_POSITION_VERB4K              Position = 53 // v4k: Yodan verb with `ku' ending (archaic)
+	Position_POSITION_VERB4R              Position = 54 // v4r: Yodan verb with `ru' ending (archaic)
+	Position_POSITION_VERB4S              Position = 55 // v4s: Yodan verb with `su' ending (archaic)
+	Position_POSITION_VERB4T              Position = 56 // v4t: Yodan verb with `tsu' ending (archaic)
+	Position_POSITION_VERB5ARU            Position = 57 // v5aru: Godan verb - -aru special class
+	Position_POSITION_VERB5B              Position = 58 // v5b: Godan verb with `bu' ending
+	Position_POSITION_VERB5G              Position = 59 // v5g: Godan verb with `gu' ending
+	Position_POSITION_VERB5K              Position = 60 // v5k: Godan verb with `ku' ending
+	Position_POSITION_VERB5KS             Position = 61 // v5k-s: Godan verb - Iku/Yuku special class
+	Position_POSITION_VERB5M              Position = 62 // v5m: Godan verb with `mu' ending
+	Position_POSITION_VERB5N              Position = 63 // v5n: Godan verb with `nu' ending
+	Position_POSITION_VERB5R              Position = 64 // v5r: Godan verb with `ru' ending
+	Position_POSITION_VERB5RI             Position = 65 // v5r-i: Godan verb with `ru' ending (irregular verb)
+	Position_POSITION_VERB5S              Position = 66 // v5s: Godan verb with `su' ending
+	Position_POSITION_VERB5T              Position = 67 // v5t: Godan verb with `tsu' ending
+	Position_POSITION_VERB5U              Position = 68 // v5u: Godan verb with `u' ending
+	Position_POSITION_VERB5US             Position = 69 // v5u-s: Godan verb with `u' ending (special class)
+	Position_POSITION_VERB_INTRANSITIVE   Position = 70 // vi: intransitive verb
+	Position_POSITION_VERB_KURU           Position = 71 // vk: Kuru verb - special class
+	Position_POSITION_VERB_NU             Position = 72 // vn: irregular nu verb
+	Position_POSITION_VERB_RU             Position = 73 // vr: irregular ru verb, plain form ends with -ri
+	Position_POSITION_VERB_SURU           Position = 74 // vs: noun or participle which takes the aux. verb suru
+	Position_POSITION_VERB_SU             Position = 75 // vs-c: su verb - precursor to the modern suru
+	Position_POSITION_VERB_IRREGULAR_SURU Position = 76 // vs-i: suru verb - irregular
+	Position_POSITION_VERB_SURU_SPECIAL   Position = 77 // vs-s: suru verb - special class
+	Position_POSITION_VERB_TRANSITIVE     Position = 78 // vt: transitive verb
+	Position_POSITION_VERB_ZURU           Position = 79 // vz: Ichidan verb - zuru verb (alternative form of -jiru verbs)
+)
+
+// Enum value maps for Position.
+var (
+	Position_name = map[int32]string{
+		0:  "POSITION_UNSPECIFIED",
+		1:  "POSITION_ADJ_I",
+		2:  "POSITION_ADJ_KU",
+		3:  "POSITION_ADJ_NA",
+		4:  "POSITION_ADJ_NARI",
+		5:  "POSITION_ADJ_NO",
+		6:  "POSITION_ADJ_PRE_NOUN",
+		7:  "POSITION_ADJ_SHIKU",
+		8:  "POSITION_ADJ_TARU",
+		9:  "POSITION_ADJ_FUNCTIONAL",
+		10: "POSITION_ADV",
+		11: "POSITION_ADV_TO",
+		12: "POSITION_AUX",
+		13: "POSITION_AUX_ADJ",
+		14: "POSITION_AUX_VERB",
+		15: "POSITION_CONJ",
+		16: "POSITION_COUNTER",
+		17: "POSITION_EXPRESSION",
+		18: "POSITION_INTERJECTION",
+		19: "POSITION_NOUN",
+		20: "POSITION_NOUN_ADV",
+		21: "POSITION_NOUN_PROPPER",
+		22: "POSITION_NOUN_PREFIX",
+		23: "POSITION_NOUN_SUFFIX",
+		24: "POSITION_NOUN_TEMPORAL",
+		25: "POSITION_NUMERIC",
+		26: "POSITION_PRONOUN",
+		27: "POSITION_PREFIX",
+		28: "POSITION_PARTICLE",
+		29: "POSITION_SUFFIX",
+		30: "POSITION_VERB1",
+		31: "POSITION_VERB2AS",
+		32: "POSITION_VERB2BK",
+		33: "POSITION_VERB2DS",
+		34: "POSITION_VERB2GK",
+		35: "POSITION_VERB2GS",
+		36: "POSITION_VERB2HK",
+		37: "POSITION_VERB2HS",
+		38: "POSITION_VERB2KK",
+		39: "POSITION_VERB2KS",
+		40: "POSITION_VERB2MS",
+		41: "POSITION_VERB2NS",
+		42: "POSITION_VERB2RK",
+		43: "POSITION_VERB2RS",
+		44: "POSITION_VERB2SS",
+		45: "POSITION_VERB2TK",
+		46: "POSITION_VERB2TS",
+		47: "POSITION_VERB2WS",
+		48: "POSITION_VERB2YK",
+		49: "POSITION_VERB2YS",
+		50: "POSITION_VERB2ZS",
+		51: "POSITION_VERB4B",
+		52: "POSITION_VERB4H",
+		53: "POSITION_VERB4K",
+		54: "POSITION_VERB4R",
+		55: "POSITION_VERB4S",
+		56: "POSITION_VERB4T",
+		57: "POSITION_VERB5ARU",
+		58: "POSITION_VERB5B",
+		59: "POSITION_VERB5G",
+		60: "POSITION_VERB5K",
+		61: "POSITION_VERB5KS",
+		62: "POSITION_VERB5M",
+		63: "POSITION_VERB5N",
+		64: "POSITION_VERB5R",
+		65: "POSITION_VERB5RI",
+		66: "POSITION_VERB5S",
+		67: "POSITION_VERB5T",
+		68: "POSITION_VERB5U",
+		69: "POSITION_VERB5US",
+		70: "POSITION_VERB_INTRANSITIVE",
+		71: "POSITION_VERB_KURU",
+		72: "POSITION_VERB_NU",
+		73: "POSITION_VERB_RU",
+		74: "POSITION_VERB_SURU",
+		75: "POSITION_VERB_SU",
+		76: "POSITION_VERB_IRREGULAR_SURU",
+		77: "POSITION_VERB_SURU_SPECIAL",
+		78: "POSITION_VERB_TRANSITIVE",
+		79: "POSITION_VERB_ZURU",
+	}
+	Position_value = map[string]int32{
+		"POSITION_UNSPECIFIED":         0,
+		"POSITION_ADJ_I":               1,
+		"POSITION_ADJ_KU":              2,
+		"POSITION_ADJ_NA":              3,
+		"POSITION_ADJ_NARI":            4,
+		"POSITION_ADJ_NO":              5,
+		"POSITION_ADJ_PRE_NOUN":        6,
+		"POSITION_ADJ_SHIKU":           7,
+		"POSITION_ADJ_TARU":            8,
+		"POSITION_ADJ_FUNCTIONAL":      9,
+		"POSITION_ADV":                 10,
+		"POSITION_ADV_TO":              11,
+		"POSITION_AUX":                 12,
+		"POSITION_AUX_ADJ":             13,
+		"POSITION_AUX_VERB":            14,
+		"POSITION_CONJ":                15,
+		"POSITION_COUNTER":             16,
+		"POSITION_EXPRESSION":          17,
+		"POSITION_INTERJECTION":        18,
+		"POSITION_NOUN":                19,
+		"POSITION_NOUN_ADV":            20,
+		"POSITION_NOUN_PROPPER":        21,
+		"POSITION_NOUN_PREFIX":         22,
+		"POSITION_NOUN_SUFFIX":         23,
+		"POSITION_NOUN_TEMPORAL":       24,
+		"POSITION_NUMERIC":             25,
+		"POSITION_PRONOUN":             26,
+		"POSITION_PREFIX":              27,
+		"POSITION_PARTICLE":            28,
+		"POSITION_SUFFIX":              29,
+		"POSITION_VERB1":               30,
+		"POSITION_VERB2AS":             31,
+		"POSITION_VERB2BK":             32,
+		"POSITION_VERB2DS":             33,
+		"POSITION_VERB2GK":             34,
+		"POSITION_VERB2GS":             35,
+		"POSITION_VERB2HK":             36,
+		"POSITION_VERB2HS":             37,
+		"POSITION_VERB2KK":             38,
+		"POSITION_VERB2KS":             39,
+		"POSITION_VERB2MS":             40,
+		"POSITION_VERB2NS":             41,
+		"POSITION_VERB2RK":             42,
+		"POSITION_VERB2RS":             43,
+		"POSITION_VERB2SS":             44,
+		"POSITION_VERB2TK":             45,
+		"POSITION_VERB2TS":             46,
+		"POSITION_VERB2WS":             47,
+		"POSITION_VERB2YK":             48,
+		"POSITION_VERB2YS":             49,
+		"POSITION_VERB2ZS":             50,
+		"POSITION_VERB4B":              51,
+		"POSITION_VERB4H":              52,
+		"POSITION_VERB4K":              53,
+		"POSITION_VERB4R":              54,
+		"POSITION_VERB4S":              55,
+		"POSITION_VERB4T":              56,
+		"POSITION_VERB5ARU":            57,
+		"POSITION_VERB5B":              58,
+		"POSITION_VERB5G":              59,
+		"POSITION_VERB5K":              60,
+		"POSITION_VERB5KS":             61,
+		"POSITION_VERB5M":              62,
+		"POSITION_VERB5N":              63,
+		"POSITION_VERB5R":              64,
+		"POSITION_VERB5RI":             65,
+		"POSITION_VERB5S":              66,
+		"POSITION_VERB5T":              67,
+		"POSITION_VERB5U":              68,
+		"POSITION_VERB5US":             69,
+		"POSITION_VERB_INTRANSITIVE":   70,
+		"POSITION_VERB_KURU":           71,
+		"POSITION_VERB_NU":             72,
+		"POSITION_VERB_RU":             73,
+		"POSITION_VERB_SURU":           74,
+		"POSITION_VERB_SU":             75,
+		"POSITION_VERB_IRREGULAR_SURU": 76,
+		"POSITION_VERB_SURU_SPECIAL":   77,
+		"POSITION_VERB_TRANSITIVE":     78,
+		"POSITION_VERB_ZURU":           79,
+	}
+)
+
+func (x Position) Enum() *Position {
+	p := new(Position)
+	*p = x
+	return p
+}
+
+func (x Position) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Position) Descriptor() protoreflect.EnumDescriptor {
+	return file_jmdict_proto_enumTypes[1].Descriptor()
+}
+
+func (Position) Type() protoreflect.EnumType {
+	return &file_jmdict_proto_enumTypes[1]
+}
+
+func (x Position) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Position.Descriptor instead.
+func (Position) EnumDescriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{1}
+}
+
+type Field int32
+
+const (
+	Field_FIELD_UNSPECIFIED  Field = 0
+	Field_FIELD_ANATOMICAL   Field = 1  // anat: anatomical term
+	Field_FIELD_ARCHITECTURE Field = 2  // archit: architecture term
+	Field_FIELD_ASTRONOMY    Field = 3  // astron: astronomy, etc. term
+	Field_FIELD_BASEBALL     Field = 4  // baseb: baseball term
+	Field_FIELD_BIOLOGY      Field = 5  // biol: biology term
+	Field_FIELD_BOTANY       Field = 6  // bot: botany term
+	Field_FIELD_BUDDHIST     Field = 7  // Buddh: Buddhist term
+	Field_FIELD_BUSINESS     Field = 8  // bus: business term
+	Field_FIELD_CHEMISTRY    Field = 9  // chem: chemistry term
+	Field_FIELD_COMPUTER     Field = 10 // comp: computer terminology
+	Field_FIELD_ECONOMICS    Field = 11 // econ: economics term
+	Field_FIELD_ENGINEERING  Field = 12 // engr: engineering term
+	Field_FIELD_FINANCE      Field = 13 // finc: finance term
+	Field_FIELD_FOOD         Field = 14 // food: food term
+	Field_FIELD_GEOLOGY      Field = 15 // geol: geology, etc. term
+	Field_FIELD_GEOMETRY     Field = 16 // geom: geometry term
+	Field_FIELD_LAW          Field = 17 // law: law, etc. term
+	Field_FIELD_LINGUISTICS  Field = 18 // ling: linguistics terminology
+	Field_FIELD_MARTIAL      Field = 19 // MA: martial arts term
+	Field_FIELD_MATHEMATICS  Field = 20 // math: mathematics
+	Field_FIELD_MEDICINE     Field = 21 // med: medicine, etc. term
+	Field_FIELD_MILITARY     Field = 22 // mil: military
+	Field_FIELD_MUSIC        Field = 23 // music: music term
+	Field_FIELD_PHYSICS      Field = 24 // physics: physics terminology
+	Field_FIELD_SHINTO       Field = 25 // Shinto: Shinto term
+	Field_FIELD_SPORTS       Field = 26 // sports: sports term
+	Field_FIELD_SUMO         Field = 27 // sumo: sumo term
+	Field_FIELD_ZOOLOGY      Field = 28 // zool: zoology term
+)
+
+// Enum value maps for Field.
+var (
+	Field_name = map[int32]string{
+		0:  "FIELD_UNSPECIFIED",
+		1:  "FIELD_ANATOMICAL",
+		2:  "FIELD_ARCHITECTURE",
+		3:  "FIELD_ASTRONOMY",
+		4:  "FIELD_BASEBALL",
+		5:  "FIELD_BIOLOGY",
+		6:  "FIELD_BOTANY",
+		7:  "FIELD_BUDDHIST",
+		8:  "FIELD_BUSINESS",
+		9:  "FIELD_CHEMISTRY",
+		10: "FIELD_COMPUTER",
+		11: "FIELD_ECONOMICS",
+		12: "FIELD_ENGINEERING",
+		13: "FIELD_FINANCE",
+		14: "FIELD_FOOD",
+		15: "FIELD_GEOLOGY",
+		16: "FIELD_GEOMETRY",
+		17: "FIELD_LAW",
+		18: "FIELD_LINGUISTICS",
+		19: "FIELD_MARTIAL",
+		20: "FIELD_MATHEMATICS",
+		21: "FIELD_MEDICINE",
+		22: "FIELD_MILITARY",
+		23: "FIELD_MUSIC",
+		24: "FIELD_PHYSICS",
+		25: "FIELD_SHINTO",
+		26: "FIELD_SPORTS",
+		27: "FIELD_SUMO",
+		28: "FIELD_ZOOLOGY",
+	}
+	Field_value = map[string]int32{
+		"FIELD_UNSPECIFIED":  0,
+		"FIELD_ANATOMICAL":   1,
+		"FIELD_ARCHITECTURE": 2,
+		"FIELD_ASTRONOMY":    3,
+		"FIELD_BASEBALL":     4,
+		"FIELD_BIOLOGY":      5,
+		"FIELD_BOTANY":       6,
+		"FIELD_BUDDHIST":     7,
+		"FIELD_BUSINESS":     8,
+		"FIELD_CHEMISTRY":    9,
+		"FIELD_COMPUTER":     10,
+		"FIELD_ECONOMICS":    11,
+		"FIELD_ENGINEERING":  12,
+		"FIELD_FINANCE":      13,
+		"FIELD_FOOD":         14,
+		"FIELD_GEOLOGY":      15,
+		"FIELD_GEOMETRY":     16,
+		"FIELD_LAW":          17,
+		"FIELD_LINGUISTICS":  18,
+		"FIELD_MARTIAL":      19,
+		"FIELD_MATHEMATICS":  20,
+		"FIELD_MEDICINE":     21,
+		"FIELD_MILITARY":     22,
+		"FIELD_MUSIC":        23,
+		"FIELD_PHYSICS":      24,
+		"FIELD_SHINTO":       25,
+		"FIELD_SPORTS":       26,
+		"FIELD_SUMO":         27,
+		"FIELD_ZOOLOGY":      28,
+	}
+)
+
+func (x Field) Enum() *Field {
+	p := new(Field)
+	*p = x
+	return p
+}
+
+func (x Field) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Field) Descriptor() protoreflect.EnumDescriptor {
+	return file_jmdict_proto_enumTypes[2].Descriptor()
+}
+
+func (Field) Type() protoreflect.EnumType {
+	return &file_jmdict_proto_enumTypes[2]
+}
+
+func (x Field) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Field.Descriptor instead.
+func (Field) EnumDescriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{2}
+}
+
+type Misc int32
+
+const (
+	Misc_MISC_UNSPECIFIED     Misc = 0
+	Misc_MISC_ABBREVIATION    Misc = 1  // abbr: abbreviation
+	Misc_MISC_ARCHAISM        Misc = 2  // arch: archaism
+	Misc_MISC_CHILD_LANGUAGE  Misc = 3  // chn: children's language
+	Misc_MISC_COLLOQUIALISM   Misc = 4  // col: colloquialism
+	Misc_MISC_DEROGATORY      Misc = 5  // derog: derogatory
+	Misc_MISC_FAMILIAR        Misc = 6  // fam: familiar language
+	Misc_MISC_FEMALE_LANGUAGE Misc = 7  // fem: female term or language
+	Misc_MISC_HONORIFIC       Misc = 8  // hon: honorific or respectful (sonkeigo) language
+	Misc_MISC_HUMBLE          Misc = 9  // hum: humble (kenjougo) language
+	Misc_MISC_IDIOMATIC       Misc = 10 // id: idiomatic expression
+	Misc_MISC_JOCULAR         Misc = 11 // joc: jocular, humorous term
+	Misc_MISC_MALE_LANGUAGE   Misc = 12 // male: male term or language
+	Misc_MISC_MANGA           Misc = 13 // m-sl: manga slang
+	Misc_MISC_OBSOLETE        Misc = 14 // obs: obsolete term
+	Misc_MISC_OBSCURE         Misc = 15 // obsc: obscure term
+	Misc_MISC_ONOMATOPOEIC    Misc = 16 // on-mim: onomatopoeic or mimetic word
+	Misc_MISC_POETICAL        Misc = 17 // poet: poetical term
+	Misc_MISC_POLITE          Misc = 18 // pol: polite (teineigo) language
+	Misc_MISC_PROVERB         Misc = 19 // proverb: proverb
+	Misc_MISC_RARE            Misc = 20 // rare: rare
+	Misc_MISC_SENSITIVE       Misc = 21 // sens: sensitive
+	Misc_MISC_SLANG           Misc = 22 // sl: slang
+	Misc_MISC_KANA_ALONE      Misc = 23 // uk: word usually written using kana alone
+	Misc_MISC_VULGAR          Misc = 24 // vulg: vulgar expression or word
+	Misc_MISC_X_RATED         Misc = 25 // X: rude or X-rated term (not displayed in educational software)
+)
+
+// Enum value maps for Misc.
+var (
+	Misc_name = map[int32]string{
+		0:  "MISC_UNSPECIFIED",
+		1:  "MISC_ABBREVIATION",
+		2:  "MISC_ARCHAISM",
+		3:  "MISC_CHILD_LANGUAGE",
+		4:  "MISC_COLLOQUIALISM",
+		5:  "MISC_DEROGATORY",
+		6:  "MISC_FAMILIAR",
+		7:  "MISC_FEMALE_LANGUAGE",
+		8:  "MISC_HONORIFIC",
+		9:  "MISC_HUMBLE",
+		10: "MISC_IDIOMATIC",
+		11: "MISC_JOCULAR",
+		12: "MISC_MALE_LANGUAGE",
+		13: "MISC_MANGA",
+		14: "MISC_OBSOLETE",
+		15: "MISC_OBSCURE",
+		16: "MISC_ONOMATOPOEIC",
+		17: "MISC_POETICAL",
+		18: "MISC_POLITE",
+		19: "MISC_PROVERB",
+		20: "MISC_RARE",
+		21: "MISC_SENSITIVE",
+		22: "MISC_SLANG",
+		23: "MISC_KANA_ALONE",
+		24: "MISC_VULGAR",
+		25: "MISC_X_RATED",
+	}
+	Misc_value = map[string]int32{
+		"MISC_UNSPECIFIED":     0,
+		"MISC_ABBREVIATION":    1,
+		"MISC_ARCHAISM":        2,
+		"MISC_CHILD_LANGUAGE":  3,
+		"MISC_COLLOQUIALISM":   4,
+		"MISC_DEROGATORY":      5,
+		"MISC_FAMILIAR":        6,
+		"MISC_FEMALE_LANGUAGE": 7,
+		"MISC_HONORIFIC":       8,
+		"MISC_HUMBLE":          9,
+		"MISC_IDIOMATIC":       10,
+		"MISC_JOCULAR":         11,
+		"MISC_MALE_LANGUAGE":   12,
+		"MISC_MANGA":           13,
+		"MISC_OBSOLETE":        14,
+		"MISC_OBSCURE":         15,
+		"MISC_ONOMATOPOEIC":    16,
+		"MISC_POETICAL":        17,
+		"MISC_POLITE":          18,
+		"MISC_PROVERB":         19,
+		"MISC_RARE":            20,
+		"MISC_SENSITIVE":       21,
+		"MISC_SLANG":           22,
+		"MISC_KANA_ALONE":      23,
+		"MISC_VULGAR":          24,
+		"MISC_X_RATED":         25,
+	}
+)
+
+func (x Misc) Enum() *Misc {
+	p := new(Misc)
+	*p = x
+	return p
+}
+
+func (x Misc) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Misc) Descriptor() protoreflect.EnumDescriptor {
+	return file_jmdict_proto_enumTypes[3].Descriptor()
+}
+
+func (Misc) Type() protoreflect.EnumType {
+	return &file_jmdict_proto_enumTypes[3]
+}
+
+func (x Misc) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Misc.Descriptor instead.
+func (Misc) EnumDescriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{3}
+}
+
+type Dialect int32
+
+const (
+	Dialect_DIALECT_UNSPECIFIED  Dialect = 0
+	Dialect_DIALECT_HOKKAIDO_BEN Dialect = 1  // hob: Hokkaido-ben
+	Dialect_DIALECT_KANSAI_BEN   Dialect = 2  // ksb: Kansai-ben
+	Dialect_DIALECT_KANTOU_BEN   Dialect = 3  // ktb: Kantou-ben
+	Dialect_DIALECT_KYOTO_BEN    Dialect = 4  // kyb: Kyoto-ben
+	Dialect_DIALECT_KYUUSHUU_BEN Dialect = 5  // kyu: Kyuushuu-ben
+	Dialect_DIALECT_NAGANO_BEN   Dialect = 6  // nab: Nagano-ben
+	Dialect_DIALECT_OSAKA_BEN    Dialect = 7  // osb: Osaka-ben
+	Dialect_DIALECT_RYUUKYUU_BEN Dialect = 8  // rkb: Ryuukyuu-ben
+	Dialect_DIALECT_TOUHOKU_BEN  Dialect = 9  // thb: Touhoku-ben
+	Dialect_DIALECT_TOSA_BEN     Dialect = 10 // tsb: Tosa-ben
+	Dialect_DIALECT_TSUGARU_BEN  Dialect = 11 // tsug: Tsugaru-ben
+)
+
+// Enum value maps for Dialect.
+var (
+	Dialect_name = map[int32]string{
+		0:  "DIALECT_UNSPECIFIED",
+		1:  "DIALECT_HOKKAIDO_BEN",
+		2:  "DIALECT_KANSAI_BEN",
+		3:  "DIALECT_KANTOU_BEN",
+		4:  "DIALECT_KYOTO_BEN",
+		5:  "DIALECT_KYUUSHUU_BEN",
+		6:  "DIALECT_NAGANO_BEN",
+		7:  "DIALECT_OSAKA_BEN",
+		8:  "DIALECT_RYUUKYUU_BEN",
+		9:  "DIALECT_TOUHOKU_BEN",
+		10: "DIALECT_TOSA_BEN",
+		11: "DIALECT_TSUGARU_BEN",
+	}
+	Dialect_value = map[string]int32{
+		"DIALECT_UNSPECIFIED":  0,
+		"DIALECT_HOKKAIDO_BEN": 1,
+		"DIALECT_KANSAI_BEN":   2,
+		"DIALECT_KANTOU_BEN":   3,
+		"DIALECT_KYOTO_BEN":    4,
+		"DIALECT_KYUUSHUU_BEN": 5,
+		"DIALECT_NAGANO_BEN":   6,
+		"DIALECT_OSAKA_BEN":    7,
+		"DIALECT_RYUUKYUU_BEN": 8,
+		"DIALECT_TOUHOKU_BEN":  9,
+		"DIALECT_TOSA_BEN":     10,
+		"DIALECT_TSUGARU_BEN":  11,
+	}
+)
+
+func (x Dialect) Enum() *Dialect {
+	p := new(Dialect)
+	*p = x
+	return p
+}
+
+func (x Dialect) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Dialect) Descriptor() protoreflect.EnumDescriptor {
+	return file_jmdict_proto_enumTypes[4].Descriptor()
+}
+
+func (Dialect) Type() protoreflect.EnumType {
+	return &file_jmdict_proto_enumTypes[4]
+}
+
+func (x Dialect) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Dialect.Descriptor instead.
+func (Dialect) EnumDescriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{4}
+}
+
+type Orthography int32
+
+const (
+	Orthography_ORTHOGRAPHY_UNSPECIFIED                Orthography = 0
+	Orthography_ORTHOGRAPHY_ATEJI                      Orthography = 1 // ateji: ateji (phonetic) reading
+	Orthography_ORTHOGRAPHY_GIKUN                      Orthography = 2 // gikun: gikun (meaning as reading) or jukujikun (special kanji reading)
+	Orthography_ORTHOGRAPHY_IRREGULAR_KANJI            Orthography = 3 // iK: word containing irregular kanji usage
+	Orthography_ORTHOGRAPHY_IRREGULAR_KANA             Orthography = 4 // ik: word containing irregular kana usage
+	Orthography_ORTHOGRAPHY_IRREGULAR_OKURIGANA        Orthography = 5 // io: irregular okurigana usage
+	Orthography_ORTHOGRAPHY_OUTDATED_KANJI             Orthography = 6 // oK: out-dated or obsolete kanji usage
+	Orthography_ORTHOGRAPHY_OUTDATED_KANA              Orthography = 7 // ok: out-dated or obsolete kana usage
+	Orthography_ORTHOGRAPHY_OUTDATED_OR_IRREGULAR_KANA Orthography = 8 // oik: old or irregular kana form
+	Orthography_ORTHOGRAPHY_KANJI_ALONE                Orthography = 9 // uK: word usually written using kanji alone
+)
+
+// Enum value maps for Orthography.
+var (
+	Orthography_name = map[int32]string{
+		0: "ORTHOGRAPHY_UNSPECIFIED",
+		1: "ORTHOGRAPHY_ATEJI",
+		2: "ORTHOGRAPHY_GIKUN",
+		3: "ORTHOGRAPHY_IRREGULAR_KANJI",
+		4: "ORTHOGRAPHY_IRREGULAR_KANA",
+		5: "ORTHOGRAPHY_IRREGULAR_OKURIGANA",
+		6: "ORTHOGRAPHY_OUTDATED_KANJI",
+		7: "ORTHOGRAPHY_OUTDATED_KANA",
+		8: "ORTHOGRAPHY_OUTDATED_OR_IRREGULAR_KANA",
+		9: "ORTHOGRAPHY_KANJI_ALONE",
+	}
+	Orthography_value = map[string]int32{
+		"ORTHOGRAPHY_UNSPECIFIED":                0,
+		"ORTHOGRAPHY_ATEJI":                      1,
+		"ORTHOGRAPHY_GIKUN":                      2,
+		"ORTHOGRAPHY_IRREGULAR_KANJI":            3,
+		"ORTHOGRAPHY_IRREGULAR_KANA":             4,
+		"ORTHOGRAPHY_IRREGULAR_OKURIGANA":        5,
+		"ORTHOGRAPHY_OUTDATED_KANJI":             6,
+		"ORTHOGRAPHY_OUTDATED_KANA":              7,
+		"ORTHOGRAPHY_OUTDATED_OR_IRREGULAR_KANA": 8,
+		"ORTHOGRAPHY_KANJI_ALONE":                9,
+	}
+)
+
+func (x Orthography) Enum() *Orthography {
+	p := new(Orthography)
+	*p = x
+	return p
+}
+
+func (x Orthography) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Orthography) Descriptor() protoreflect.EnumDescriptor {
+	return file_jmdict_proto_enumTypes[5].Descriptor()
+}
+
+func (Orthography) Type() protoreflect.EnumType {
+	return &file_jmdict_proto_enumTypes[5]
+}
+
+func (x Orthography) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Orthography.Descriptor instead.
+func (Orthography) EnumDescriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{5}
+}
+
+type GetEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntSeq        uint64                 `protobuf:"varint,1,opt,name=ent_seq,json=entSeq,proto3" json:"ent_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntryRequest) Reset() {
+	*x = GetEntryRequest{}
+	mi := &file_jmdict_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntryRequest) ProtoMessage() {}
+
+func (x *GetEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetEntryRequest) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetEntryRequest) GetEntSeq() uint64 {
+	if x != nil {
+		return x.EntSeq
+	}
+	return 0
+}
+
+type SearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Mode  SearchMode             `protobuf:"varint,2,opt,name=mode,proto3,enum=jmdict.v1.SearchMode" json:"mode,omitempty"`
+	// Also match the dictionary forms of an inflected query, in exact and
+	// romaji modes.
+	Deinflect bool `protobuf:"varint,3,opt,name=deinflect,proto3" json:"deinflect,omitempty"`
+	// The page of results to return. The limit defaults to 20 and may be
+	// at most 100.
+	Offset        int32 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_jmdict_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetMode() SearchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SearchMode_SEARCH_MODE_UNSPECIFIED
+}
+
+func (x *SearchRequest) GetDeinflect() bool {
+	if x != nil {
+		return x.Deinflect
+	}
+	return false
+}
+
+func (x *SearchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of matches, of which the page holds those from offset.
+	Total         int32    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Matches       []*Match `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_jmdict_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type Match struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Entry *Entry                 `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// For a match found by deinflection, the dictionary form which matched
+	// and the inflections undone, from the innermost outwards.
+	Deinflected   string   `protobuf:"bytes,2,opt,name=deinflected,proto3" json:"deinflected,omitempty"`
+	Inflections   []string `protobuf:"bytes,3,rep,name=inflections,proto3" json:"inflections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Match) Reset() {
+	*x = Match{}
+	mi := &file_jmdict_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Match) ProtoMessage() {}
+
+func (x *Match) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Match.ProtoReflect.Descriptor instead.
+func (*Match) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Match) GetEntry() *Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *Match) GetDeinflected() string {
+	if x != nil {
+		return x.Deinflected
+	}
+	return ""
+}
+
+func (x *Match) GetInflections() []string {
+	if x != nil {
+		return x.Inflections
+	}
+	return nil
+}
+
+type DeinflectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Word  string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	// Return only the forms which lead to entries.
+	MatchedOnly   bool `protobuf:"varint,2,opt,name=matched_only,json=matchedOnly,proto3" json:"matched_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeinflectRequest) Reset() {
+	*x = DeinflectRequest{}
+	mi := &file_jmdict_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeinflectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeinflectRequest) ProtoMessage() {}
+
+func (x *DeinflectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeinflectRequest.ProtoReflect.Descriptor instead.
+func (*DeinflectRequest) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeinflectRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *DeinflectRequest) GetMatchedOnly() bool {
+	if x != nil {
+		return x.MatchedOnly
+	}
+	return false
+}
+
+type DeinflectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deinflections []*Deinflection        `protobuf:"bytes,1,rep,name=deinflections,proto3" json:"deinflections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeinflectResponse) Reset() {
+	*x = DeinflectResponse{}
+	mi := &file_jmdict_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeinflectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeinflectResponse) ProtoMessage() {}
+
+func (x *DeinflectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeinflectResponse.ProtoReflect.Descriptor instead.
+func (*DeinflectResponse) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeinflectResponse) GetDeinflections() []*Deinflection {
+	if x != nil {
+		return x.Deinflections
+	}
+	return nil
+}
+
+type Deinflection struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Word        string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Inflections []string               `protobuf:"bytes,2,rep,name=inflections,proto3" json:"inflections,omitempty"`
+	// The entries with a keb or reb equal to word whose part-of-speech
+	// agrees with the inflections.
+	EntSeq        []uint64 `protobuf:"varint,3,rep,packed,name=ent_seq,json=entSeq,proto3" json:"ent_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Deinflection) Reset() {
+	*x = Deinflection{}
+	mi := &file_jmdict_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deinflection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deinflection) ProtoMessage() {}
+
+func (x *Deinflection) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deinflection.ProtoReflect.Descriptor instead.
+func (*Deinflection) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Deinflection) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Deinflection) GetInflections() []string {
+	if x != nil {
+		return x.Inflections
+	}
+	return nil
+}
+
+func (x *Deinflection) GetEntSeq() []uint64 {
+	if x != nil {
+		return x.EntSeq
+	}
+	return nil
+}
+
+type ListEntriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A filter expression, as parsed by jmdict.ParseFilter, for instance
+	// "pri:news1 pos:v5* -misc:arch". Empty selects every entry.
+	Filter        string `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntriesRequest) Reset() {
+	*x = ListEntriesRequest{}
+	mi := &file_jmdict_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntriesRequest) ProtoMessage() {}
+
+func (x *ListEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListEntriesRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+type Entry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntSeq        uint64                 `protobuf:"varint,1,opt,name=ent_seq,json=entSeq,proto3" json:"ent_seq,omitempty"`
+	KEle          []*KEle                `protobuf:"bytes,2,rep,name=k_ele,json=kEle,proto3" json:"k_ele,omitempty"`
+	REle          []*REle                `protobuf:"bytes,3,rep,name=r_ele,json=rEle,proto3" json:"r_ele,omitempty"`
+	Info          *Info                  `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
+	Sense         []*Sense               `protobuf:"bytes,5,rep,name=sense,proto3" json:"sense,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entry) Reset() {
+	*x = Entry{}
+	mi := &file_jmdict_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entry) ProtoMessage() {}
+
+func (x *Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entry.ProtoReflect.Descriptor instead.
+func (*Entry) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Entry) GetEntSeq() uint64 {
+	if x != nil {
+		return x.EntSeq
+	}
+	return 0
+}
+
+func (x *Entry) GetKEle() []*KEle {
+	if x != nil {
+		return x.KEle
+	}
+	return nil
+}
+
+func (x *Entry) GetREle() []*REle {
+	if x != nil {
+		return x.REle
+	}
+	return nil
+}
+
+func (x *Entry) GetInfo() *Info {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *Entry) GetSense() []*Sense {
+	if x != nil {
+		return x.Sense
+	}
+	return nil
+}
+
+type KEle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keb           string                 `protobuf:"bytes,1,opt,name=keb,proto3" json:"keb,omitempty"`
+	KeInf         []Orthography          `protobuf:"varint,2,rep,packed,name=ke_inf,json=keInf,proto3,enum=jmdict.v1.Orthography" json:"ke_inf,omitempty"`
+	KePri         []string               `protobuf:"bytes,3,rep,name=ke_pri,json=kePri,proto3" json:"ke_pri,omitempty"`
+	KeInfCode     []string               `protobuf:"bytes,4,rep,name=ke_inf_code,json=keInfCode,proto3" json:"ke_inf_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KEle) Reset() {
+	*x = KEle{}
+	mi := &file_jmdict_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KEle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KEle) ProtoMessage() {}
+
+func (x *KEle) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KEle.ProtoReflect.Descriptor instead.
+func (*KEle) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *KEle) GetKeb() string {
+	if x != nil {
+		return x.Keb
+	}
+	return ""
+}
+
+func (x *KEle) GetKeInf() []Orthography {
+	if x != nil {
+		return x.KeInf
+	}
+	return nil
+}
+
+func (x *KEle) GetKePri() []string {
+	if x != nil {
+		return x.KePri
+	}
+	return nil
+}
+
+func (x *KEle) GetKeInfCode() []string {
+	if x != nil {
+		return x.KeInfCode
+	}
+	return nil
+}
+
+type REle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reb           string                 `protobuf:"bytes,1,opt,name=reb,proto3" json:"reb,omitempty"`
+	ReNokanji     bool                   `protobuf:"varint,2,opt,name=re_nokanji,json=reNokanji,proto3" json:"re_nokanji,omitempty"`
+	ReRestr       []string               `protobuf:"bytes,3,rep,name=re_restr,json=reRestr,proto3" json:"re_restr,omitempty"`
+	ReInf         []Orthography          `protobuf:"varint,4,rep,packed,name=re_inf,json=reInf,proto3,enum=jmdict.v1.Orthography" json:"re_inf,omitempty"`
+	RePri         []string               `protobuf:"bytes,5,rep,name=re_pri,json=rePri,proto3" json:"re_pri,omitempty"`
+	ReInfCode     []string               `protobuf:"bytes,6,rep,name=re_inf_code,json=reInfCode,proto3" json:"re_inf_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *REle) Reset() {
+	*x = REle{}
+	mi := &file_jmdict_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *REle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*REle) ProtoMessage() {}
+
+func (x *REle) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use REle.ProtoReflect.Descriptor instead.
+func (*REle) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *REle) GetReb() string {
+	if x != nil {
+		return x.Reb
+	}
+	return ""
+}
+
+func (x *REle) GetReNokanji() bool {
+	if x != nil {
+		return x.ReNokanji
+	}
+	return false
+}
+
+func (x *REle) GetReRestr() []string {
+	if x != nil {
+		return x.ReRestr
+	}
+	return nil
+}
+
+func (x *REle) GetReInf() []Orthography {
+	if x != nil {
+		return x.ReInf
+	}
+	return nil
+}
+
+func (x *REle) GetRePri() []string {
+	if x != nil {
+		return x.RePri
+	}
+	return nil
+}
+
+func (x *REle) GetReInfCode() []string {
+	if x != nil {
+		return x.ReInfCode
+	}
+	return nil
+}
+
+type Info struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*Links               `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	Bibl          []*Bibl                `protobuf:"bytes,2,rep,name=bibl,proto3" json:"bibl,omitempty"`
+	Etym          []string               `protobuf:"bytes,3,rep,name=etym,proto3" json:"etym,omitempty"`
+	Audit         []*Audit               `protobuf:"bytes,4,rep,name=audit,proto3" json:"audit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Info) Reset() {
+	*x = Info{}
+	mi := &file_jmdict_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Info) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Info) ProtoMessage() {}
+
+func (x *Info) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Info.ProtoReflect.Descriptor instead.
+func (*Info) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Info) GetLinks() []*Links {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Info) GetBibl() []*Bibl {
+	if x != nil {
+		return x.Bibl
+	}
+	return nil
+}
+
+func (x *Info) GetEtym() []string {
+	if x != nil {
+		return x.Etym
+	}
+	return nil
+}
+
+func (x *Info) GetAudit() []*Audit {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+type Links struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LinkTag       string                 `protobuf:"bytes,1,opt,name=link_tag,json=linkTag,proto3" json:"link_tag,omitempty"`
+	LinkDesc      string                 `protobuf:"bytes,2,opt,name=link_desc,json=linkDesc,proto3" json:"link_desc,omitempty"`
+	LinkUri       string                 `protobuf:"bytes,3,opt,name=link_uri,json=linkUri,proto3" json:"link_uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Links) Reset() {
+	*x = Links{}
+	mi := &file_jmdict_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Links) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Links) ProtoMessage() {}
+
+func (x *Links) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Links.ProtoReflect.Descriptor instead.
+func (*Links) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Links) GetLinkTag() string {
+	if x != nil {
+		return x.LinkTag
+	}
+	return ""
+}
+
+func (x *Links) GetLinkDesc() string {
+	if x != nil {
+		return x.LinkDesc
+	}
+	return ""
+}
+
+func (x *Links) GetLinkUri() string {
+	if x != nil {
+		return x.LinkUri
+	}
+	return ""
+}
+
+type Bibl struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BibTag        string                 `protobuf:"bytes,1,opt,name=bib_tag,json=bibTag,proto3" json:"bib_tag,omitempty"`
+	BibTxt        string                 `protobuf:"bytes,2,opt,name=bib_txt,json=bibTxt,proto3" json:"bib_txt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bibl) Reset() {
+	*x = Bibl{}
+	mi := &file_jmdict_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bibl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bibl) ProtoMessage() {}
+
+func (x *Bibl) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bibl.ProtoReflect.Descriptor instead.
+func (*Bibl) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Bibl) GetBibTag() string {
+	if x != nil {
+		return x.BibTag
+	}
+	return ""
+}
+
+func (x *Bibl) GetBibTxt() string {
+	if x != nil {
+		return x.BibTxt
+	}
+	return ""
+}
+
+type Audit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdDate       string                 `protobuf:"bytes,1,opt,name=upd_date,json=updDate,proto3" json:"upd_date,omitempty"`
+	UpdDetl       string                 `protobuf:"bytes,2,opt,name=upd_detl,json=updDetl,proto3" json:"upd_detl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Audit) Reset() {
+	*x = Audit{}
+	mi := &file_jmdict_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Audit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Audit) ProtoMessage() {}
+
+func (x *Audit) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Audit.ProtoReflect.Descriptor instead.
+func (*Audit) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Audit) GetUpdDate() string {
+	if x != nil {
+		return x.UpdDate
+	}
+	return ""
+}
+
+func (x *Audit) GetUpdDetl() string {
+	if x != nil {
+		return x.UpdDetl
+	}
+	return ""
+}
+
+type Sense struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stagk         []string               `protobuf:"bytes,1,rep,name=stagk,proto3" json:"stagk,omitempty"`
+	Stagr         []string               `protobuf:"bytes,2,rep,name=stagr,proto3" json:"stagr,omitempty"`
+	Pos           []Position             `protobuf:"varint,3,rep,packed,name=pos,proto3,enum=jmdict.v1.Position" json:"pos,omitempty"`
+	Xref          []string               `protobuf:"bytes,4,rep,name=xref,proto3" json:"xref,omitempty"`
+	Ant           []string               `protobuf:"bytes,5,rep,name=ant,proto3" json:"ant,omitempty"`
+	Field         []Field                `protobuf:"varint,6,rep,packed,name=field,proto3,enum=jmdict.v1.Field" json:"field,omitempty"`
+	Misc          []Misc                 `protobuf:"varint,7,rep,packed,name=misc,proto3,enum=jmdict.v1.Misc" json:"misc,omitempty"`
+	SInf          []string               `protobuf:"bytes,8,rep,name=s_inf,json=sInf,proto3" json:"s_inf,omitempty"`
+	Lsource       []*LSource             `protobuf:"bytes,9,rep,name=lsource,proto3" json:"lsource,omitempty"`
+	Dial          []Dialect              `protobuf:"varint,10,rep,packed,name=dial,proto3,enum=jmdict.v1.Dialect" json:"dial,omitempty"`
+	Gloss         []*Gloss               `protobuf:"bytes,11,rep,name=gloss,proto3" json:"gloss,omitempty"`
+	Example       []string               `protobuf:"bytes,12,rep,name=example,proto3" json:"example,omitempty"`
+	PosCode       []string               `protobuf:"bytes,13,rep,name=pos_code,json=posCode,proto3" json:"pos_code,omitempty"`
+	FieldCode     []string               `protobuf:"bytes,14,rep,name=field_code,json=fieldCode,proto3" json:"field_code,omitempty"`
+	MiscCode      []string               `protobuf:"bytes,15,rep,name=misc_code,json=miscCode,proto3" json:"misc_code,omitempty"`
+	DialCode      []string               `protobuf:"bytes,16,rep,name=dial_code,json=dialCode,proto3" json:"dial_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sense) Reset() {
+	*x = Sense{}
+	mi := &file_jmdict_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sense) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sense) ProtoMessage() {}
+
+func (x *Sense) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sense.ProtoReflect.Descriptor instead.
+func (*Sense) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Sense) GetStagk() []string {
+	if x != nil {
+		return x.Stagk
+	}
+	return nil
+}
+
+func (x *Sense) GetStagr() []string {
+	if x != nil {
+		return x.Stagr
+	}
+	return nil
+}
+
+func (x *Sense) GetPos() []Position {
+	if x != nil {
+		return x.Pos
+	}
+	return nil
+}
+
+func (x *Sense) GetXref() []string {
+	if x != nil {
+		return x.Xref
+	}
+	return nil
+}
+
+func (x *Sense) GetAnt() []string {
+	if x != nil {
+		return x.Ant
+	}
+	return nil
+}
+
+func (x *Sense) GetField() []Field {
+	if x != nil {
+		return x.Field
+	}
+	return nil
+}
+
+func (x *Sense) GetMisc() []Misc {
+	if x != nil {
+		return x.Misc
+	}
+	return nil
+}
+
+func (x *Sense) GetSInf() []string {
+	if x != nil {
+		return x.SInf
+	}
+	return nil
+}
+
+func (x *Sense) GetLsource() []*LSource {
+	if x != nil {
+		return x.Lsource
+	}
+	return nil
+}
+
+func (x *Sense) GetDial() []Dialect {
+	if x != nil {
+		return x.Dial
+	}
+	return nil
+}
+
+func (x *Sense) GetGloss() []*Gloss {
+	if x != nil {
+		return x.Gloss
+	}
+	return nil
+}
+
+func (x *Sense) GetExample() []string {
+	if x != nil {
+		return x.Example
+	}
+	return nil
+}
+
+func (x *Sense) GetPosCode() []string {
+	if x != nil {
+		return x.PosCode
+	}
+	return nil
+}
+
+func (x *Sense) GetFieldCode() []string {
+	if x != nil {
+		return x.FieldCode
+	}
+	return nil
+}
+
+func (x *Sense) GetMiscCode() []string {
+	if x != nil {
+		return x.MiscCode
+	}
+	return nil
+}
+
+func (x *Sense) GetDialCode() []string {
+	if x != nil {
+		return x.DialCode
+	}
+	return nil
+}
+
+type LSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lang          string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	LsType        string                 `protobuf:"bytes,2,opt,name=ls_type,json=lsType,proto3" json:"ls_type,omitempty"`
+	LsWasei       string                 `protobuf:"bytes,3,opt,name=ls_wasei,json=lsWasei,proto3" json:"ls_wasei,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LSource) Reset() {
+	*x = LSource{}
+	mi := &file_jmdict_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LSource) ProtoMessage() {}
+
+func (x *LSource) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LSource.ProtoReflect.Descriptor instead.
+func (*LSource) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LSource) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *LSource) GetLsType() string {
+	if x != nil {
+		return x.LsType
+	}
+	return ""
+}
+
+func (x *LSource) GetLsWasei() string {
+	if x != nil {
+		return x.LsWasei
+	}
+	return ""
+}
+
+func (x *LSource) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type Gloss struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lang          string                 `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Gloss) Reset() {
+	*x = Gloss{}
+	mi := &file_jmdict_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Gloss) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gloss) ProtoMessage() {}
+
+func (x *Gloss) ProtoReflect() protoreflect.Message {
+	mi := &file_jmdict_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gloss.ProtoReflect.Descriptor instead.
+func (*Gloss) Descriptor() ([]byte, []int) {
+	return file_jmdict_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Gloss) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *Gloss) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+var File_jmdict_proto protoreflect.FileDescriptor
+
+const file_jmdict_proto_rawDesc = "" +
+	"\n" +
+	"\fjmdict.proto\x12\tjmdict.v1\"*\n" +
+	"\x0fGetEntryRequest\x12\x17\n" +
+	"\aent_seq\x18\x01 \x01(\x04R\x06entSeq\"\x9c\x01\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12)\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x15.jmdict.v1.SearchModeR\x04mode\x12\x1c\n" +
+	"\tdeinflect\x18\x03 \x01(\bR\tdeinflect\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"R\n" +
+	"\x0eSearchResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12*\n" +
+	"\amatches\x18\x02 \x03(\v2\x10.jmdict.v1.MatchR\amatches\"s\n" +
+	"\x05Match\x12&\n" +
+	"\x05entry\x18\x01 \x01(\v2\x10.jmdict.v1.EntryR\x05entry\x12 \n" +
+	"\vdeinflected\x18\x02 \x01(\tR\vdeinflected\x12 \n" +
+	"\vinflections\x18\x03 \x03(\tR\vinflections\"I\n" +
+	"\x10DeinflectRequest\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12!\n" +
+	"\fmatched_only\x18\x02 \x01(\bR\vmatchedOnly\"R\n" +
+	"\x11DeinflectResponse\x12=\n" +
+	"\rdeinflections\x18\x01 \x03(\v2\x17.jmdict.v1.DeinflectionR\rdeinflections\"]\n" +
+	"\fDeinflection\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12 \n" +
+	"\vinflections\x18\x02 \x03(\tR\vinflections\x12\x17\n" +
+	"\aent_seq\x18\x03 \x03(\x04R\x06entSeq\",\n" +
+	"\x12ListEntriesRequest\x12\x16\n" +
+	"\x06filter\x18\x01 \x01(\tR\x06filter\"\xb9\x01\n" +
+	"\x05Entry\x12\x17\n" +
+	"\aent_seq\x18\x01 \x01(\x04R\x06entSeq\x12$\n" +
+	"\x05k_ele\x18\x02 \x03(\v2\x0f.jmdict.v1.KEleR\x04kEle\x12$\n" +
+	"\x05r_ele\x18\x03 \x03(\v2\x0f.jmdict.v1.REleR\x04rEle\x12#\n" +
+	"\x04info\x18\x04 \x01(\v2\x0f.jmdict.v1.InfoR\x04info\x12&\n" +
+	"\x05sense\x18\x05 \x03(\v2\x10.jmdict.v1.SenseR\x05sense\"~\n" +
+	"\x04KEle\x12\x10\n" +
+	"\x03keb\x18\x01 \x01(\tR\x03keb\x12-\n" +
+	"\x06ke_inf\x18\x02 \x03(\x0e2\x16.jmdict.v1.OrthographyR\x05keInf\x12\x15\n" +
+	"\x06ke_pri\x18\x03 \x03(\tR\x05kePri\x12\x1e\n" +
+	"\vke_inf_code\x18\x04 \x03(\tR\tkeInfCode\"\xb8\x01\n" +
+	"\x04REle\x12\x10\n" +
+	"\x03reb\x18\x01 \x01(\tR\x03reb\x12\x1d\n" +
+	"\n" +
+	"re_nokanji\x18\x02 \x01(\bR\treNokanji\x12\x19\n" +
+	"\bre_restr\x18\x03 \x03(\tR\areRestr\x12-\n" +
+	"\x06re_inf\x18\x04 \x03(\x0e2\x16.jmdict.v1.OrthographyR\x05reInf\x12\x15\n" +
+	"\x06re_pri\x18\x05 \x03(\tR\x05rePri\x12\x1e\n" +
+	"\vre_inf_code\x18\x06 \x03(\tR\treInfCode\"\x8f\x01\n" +
+	"\x04Info\x12&\n" +
+	"\x05links\x18\x01 \x03(\v2\x10.jmdict.v1.LinksR\x05links\x12#\n" +
+	"\x04bibl\x18\x02 \x03(\v2\x0f.jmdict.v1.BiblR\x04bibl\x12\x12\n" +
+	"\x04etym\x18\x03 \x03(\tR\x04etym\x12&\n" +
+	"\x05audit\x18\x04 \x03(\v2\x10.jmdict.v1.AuditR\x05audit\"Z\n" +
+	"\x05Links\x12\x19\n" +
+	"\blink_tag\x18\x01 \x01(\tR\alinkTag\x12\x1b\n" +
+	"\tlink_desc\x18\x02 \x01(\tR\blinkDesc\x12\x19\n" +
+	"\blink_uri\x18\x03 \x01(\tR\alinkUri\"8\n" +
+	"\x04Bibl\x12\x17\n" +
+	"\abib_tag\x18\x01 \x01(\tR\x06bibTag\x12\x17\n" +
+	"\abib_txt\x18\x02 \x01(\tR\x06bibTxt\"=\n" +
+	"\x05Audit\x12\x19\n" +
+	"\bupd_date\x18\x01 \x01(\tR\aupdDate\x12\x19\n" +
+	"\bupd_detl\x18\x02 \x01(\tR\aupdDetl\"\xee\x03\n" +
+	"\x05Sense\x12\x14\n" +
+	"\x05stagk\x18\x01 \x03(\tR\x05stagk\x12\x14\n" +
+	"\x05stagr\x18\x02 \x03(\tR\x05stagr\x12%\n" +
+	"\x03pos\x18\x03 \x03(\x0e2\x13.jmdict.v1.PositionR\x03pos\x12\x12\n" +
+	"\x04xref\x18\x04 \x03(\tR\x04xref\x12\x10\n" +
+	"\x03ant\x18\x05 \x03(\tR\x03ant\x12&\n" +
+	"\x05field\x18\x06 \x03(\x0e2\x10.jmdict.v1.FieldR\x05field\x12#\n" +
+	"\x04misc\x18\a \x03(\x0e2\x0f.jmdict.v1.MiscR\x04misc\x12\x13\n" +
+	"\x05s_inf\x18\b \x03(\tR\x04sInf\x12,\n" +
+	"\alsource\x18\t \x03(\v2\x12.jmdict.v1.LSourceR\alsource\x12&\n" +
+	"\x04dial\x18\n" +
+	" \x03(\x0e2\x12.jmdict.v1.DialectR\x04dial\x12&\n" +
+	"\x05gloss\x18\v \x03(\v2\x10.jmdict.v1.GlossR\x05gloss\x12\x18\n" +
+	"\aexample\x18\f \x03(\tR\aexample\x12\x19\n" +
+	"\bpos_code\x18\r \x03(\tR\aposCode\x12\x1d\n" +
+	"\n" +
+	"field_code\x18\x0e \x03(\tR\tfieldCode\x12\x1b\n" +
+	"\tmisc_code\x18\x0f \x03(\tR\bmiscCode\x12\x1b\n" +
+	"\tdial_code\x18\x10 \x03(\tR\bdialCode\"e\n" +
+	"\aLSource\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\x12\x17\n" +
+	"\als_type\x18\x02 \x01(\tR\x06lsType\x12\x19\n" +
+	"\bls_wasei\x18\x03 \x01(\tR\alsWasei\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\"/\n" +
+	"\x05Gloss\x12\x12\n" +
+	"\x04lang\x18\x01 \x01(\tR\x04lang\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text*\x9e\x01\n" +
+	"\n" +
+	"SearchMode\x12\x1b\n" +
+	"\x17SEARCH_MODE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11SEARCH_MODE_EXACT\x10\x01\x12\x16\n" +
+	"\x12SEARCH_MODE_PREFIX\x10\x02\x12\x15\n" +
+	"\x11SEARCH_MODE_GLOSS\x10\x03\x12\x16\n" +
+	"\x12SEARCH_MODE_ROMAJI\x10\x04\x12\x15\n" +
+	"\x11SEARCH_MODE_KANJI\x10\x05*\xa4\x0e\n" +
+	"\bPosition\x12\x18\n" +
+	"\x14POSITION_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0ePOSITION_ADJ_I\x10\x01\x12\x13\n" +
+	"\x0fPOSITION_ADJ_KU\x10\x02\x12\x13\n" +
+	"\x0fPOSITION_ADJ_NA\x10\x03\x12\x15\n" +
+	"\x11POSITION_ADJ_NARI\x10\x04\x12\x13\n" +
+	"\x0fPOSITION_ADJ_NO\x10\x05\x12\x19\n" +
+	"\x15POSITION_ADJ_PRE_NOUN\x10\x06\x12\x16\n" +
+	"\x12POSITION_ADJ_SHIKU\x10\a\x12\x15\n" +
+	"\x11POSITION_ADJ_TARU\x10\b\x12\x1b\n" +
+	"\x17POSITION_ADJ_FUNCTIONAL\x10\t\x12\x10\n" +
+	"\fPOSITION_ADV\x10\n" +
+	"\x12\x13\n" +
+	"\x0fPOSITION_ADV_TO\x10\v\x12\x10\n" +
+	"\fPOSITION_AUX\x10\f\x12\x14\n" +
+	"\x10POSITION_AUX_ADJ\x10\r\x12\x15\n" +
+	"\x11POSITION_AUX_VERB\x10\x0e\x12\x11\n" +
+	"\rPOSITION_CONJ\x10\x0f\x12\x14\n" +
+	"\x10POSITION_COUNTER\x10\x10\x12\x17\n" +
+	"\x13POSITION_EXPRESSION\x10\x11\x12\x19\n" +
+	"\x15POSITION_INTERJECTION\x10\x12\x12\x11\n" +
+	"\rPOSITION_NOUN\x10\x13\x12\x15\n" +
+	"\x11POSITION_NOUN_ADV\x10\x14\x12\x19\n" +
+	"\x15POSITION_NOUN_PROPPER\x10\x15\x12\x18\n" +
+	"\x14POSITION_NOUN_PREFIX\x10\x16\x12\x18\n" +
+	"\x14POSITION_NOUN_SUFFIX\x10\x17\x12\x1a\n" +
+	"\x16POSITION_NOUN_TEMPORAL\x10\x18\x12\x14\n" +
+	"\x10POSITION_NUMERIC\x10\x19\x12\x14\n" +
+	"\x10POSITION_PRONOUN\x10\x1a\x12\x13\n" +
+	"\x0fPOSITION_PREFIX\x10\x1b\x12\x15\n" +
+	"\x11POSITION_PARTICLE\x10\x1c\x12\x13\n" +
+	"\x0fPOSITION_SUFFIX\x10\x1d\x12\x12\n" +
+	"\x0ePOSITION_VERB1\x10\x1e\x12\x14\n" +
+	"\x10POSITION_VERB2AS\x10\x1f\x12\x14\n" +
+	"\x10POSITION_VERB2BK\x10 \x12\x14\n" +
+	"\x10POSITION_VERB2DS\x10!\x12\x14\n" +
+	"\x10POSITION_VERB2GK\x10\"\x12\x14\n" +
+	"\x10POSITION_VERB2GS\x10#\x12\x14\n" +
+	"\x10POSITION_VERB2HK\x10$\x12\x14\n" +
+	"\x10POSITION_VERB2HS\x10%\x12\x14\n" +
+	"\x10POSITION_VERB2KK\x10&\x12\x14\n" +
+	"\x10POSITION_VERB2KS\x10'\x12\x14\n" +
+	"\x10POSITION_VERB2MS\x10(\x12\x14\n" +
+	"\x10POSITION_VERB2NS\x10)\x12\x14\n" +
+	"\x10POSITION_VERB2RK\x10*\x12\x14\n" +
+	"\x10POSITION_VERB2RS\x10+\x12\x14\n" +
+	"\x10POSITION_VERB2SS\x10,\x12\x14\n" +
+	"\x10POSITION_VERB2TK\x10-\x12\x14\n" +
+	"\x10POSITION_VERB2TS\x10.\x12\x14\n" +
+	"\x10POSITION_VERB2WS\x10/\x12\x14\n" +
+	"\x10POSITION_VERB2YK\x100\x12\x14\n" +
+	"\x10POSITION_VERB2YS\x101\x12\x14\n" +
+	"\x10POSITION_VERB2ZS\x102\x12\x13\n" +
+	"\x0fPOSITION_VERB4B\x103\x12\x13\n" +
+	"\x0fPOSITION_VERB4H\x104\x12\x13\n" +
+	"\x0fPOSITION_VERB4K\x105\x12\x13\n" +
+	"\x0fPOSITION_VERB4R\x106\x12\x13\n" +
+	"\x0fPOSITION_VERB4S\x107\x12\x13\n" +
+	"\x0fPOSITION_VERB4T\x108\x12\x15\n" +
+	"\x11POSITION_VERB5ARU\x109\x12\x13\n" +
+	"\x0fPOSITION_VERB5B\x10:\x12\x13\n" +
+	"\x0fPOSITION_VERB5G\x10;\x12\x13\n" +
+	"\x0fPOSITION_VERB5K\x10<\x12\x14\n" +
+	"\x10POSITION_VERB5KS\x10=\x12\x13\n" +
+	"\x0fPOSITION_VERB5M\x10>\x12\x13\n" +
+	"\x0fPOSITION_VERB5N\x10?\x12\x13\n" +
+	"\x0fPOSITION_VERB5R\x10@\x12\x14\n" +
+	"\x10POSITION_VERB5RI\x10A\x12\x13\n" +
+	"\x0fPOSITION_VERB5S\x10B\x12\x13\n" +
+	"\x0fPOSITION_VERB5T\x10C\x12\x13\n" +
+	"\x0fPOSITION_VERB5U\x10D\x12\x14\n" +
+	"\x10POSITION_VERB5US\x10E\x12\x1e\n" +
+	"\x1aPOSITION_VERB_INTRANSITIVE\x10F\x12\x16\n" +
+	"\x12POSITION_VERB_KURU\x10G\x12\x14\n" +
+	"\x10POSITION_VERB_NU\x10H\x12\x14\n" +
+	"\x10POSITION_VERB_RU\x10I\x12\x16\n" +
+	"\x12POSITION_VERB_SURU\x10J\x12\x14\n" +
+	"\x10POSITION_VERB_SU\x10K\x12 \n" +
+	"\x1cPOSITION_VERB_IRREGULAR_SURU\x10L\x12\x1e\n" +
+	"\x1aPOSITION_VERB_SURU_SPECIAL\x10M\x12\x1c\n" +
+	"\x18POSITION_VERB_TRANSITIVE\x10N\x12\x16\n" +
+	"\x12POSITION_VERB_ZURU\x10O*\xc4\x04\n" +
+	"\x05Field\x12\x15\n" +
+	"\x11FIELD_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10FIELD_ANATOMICAL\x10\x01\x12\x16\n" +
+	"\x12FIELD_ARCHITECTURE\x10\x02\x12\x13\n" +
+	"\x0fFIELD_ASTRONOMY\x10\x03\x12\x12\n" +
+	"\x0eFIELD_BASEBALL\x10\x04\x12\x11\n" +
+	"\rFIELD_BIOLOGY\x10\x05\x12\x10\n" +
+	"\fFIELD_BOTANY\x10\x06\x12\x12\n" +
+	"\x0eFIELD_BUDDHIST\x10\a\x12\x12\n" +
+	"\x0eFIELD_BUSINESS\x10\b\x12\x13\n" +
+	"\x0fFIELD_CHEMISTRY\x10\t\x12\x12\n" +
+	"\x0eFIELD_COMPUTER\x10\n" +
+	"\x12\x13\n" +
+	"\x0fFIELD_ECONOMICS\x10\v\x12\x15\n" +
+	"\x11FIELD_ENGINEERING\x10\f\x12\x11\n" +
+	"\rFIELD_FINANCE\x10\r\x12\x0e\n" +
+	"\n" +
+	"FIELD_FOOD\x10\x0e\x12\x11\n" +
+	"\rFIELD_GEOLOGY\x10\x0f\x12\x12\n" +
+	"\x0eFIELD_GEOMETRY\x10\x10\x12\r\n" +
+	"\tFIELD_LAW\x10\x11\x12\x15\n" +
+	"\x11FIELD_LINGUISTICS\x10\x12\x12\x11\n" +
+	"\rFIELD_MARTIAL\x10\x13\x12\x15\n" +
+	"\x11FIELD_MATHEMATICS\x10\x14\x12\x12\n" +
+	"\x0eFIELD_MEDICINE\x10\x15\x12\x12\n" +
+	"\x0eFIELD_MILITARY\x10\x16\x12\x0f\n" +
+	"\vFIELD_MUSIC\x10\x17\x12\x11\n" +
+	"\rFIELD_PHYSICS\x10\x18\x12\x10\n" +
+	"\fFIELD_SHINTO\x10\x19\x12\x10\n" +
+	"\fFIELD_SPORTS\x10\x1a\x12\x0e\n" +
+	"\n" +
+	"FIELD_SUMO\x10\x1b\x12\x11\n" +
+	"\rFIELD_ZOOLOGY\x10\x1c*\x89\x04\n" +
+	"\x04Misc\x12\x14\n" +
+	"\x10MISC_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11MISC_ABBREVIATION\x10\x01\x12\x11\n" +
+	"\rMISC_ARCHAISM\x10\x02\x12\x17\n" +
+	"\x13MISC_CHILD_LANGUAGE\x10\x03\x12\x16\n" +
+	"\x12MISC_COLLOQUIALISM\x10\x04\x12\x13\n" +
+	"\x0fMISC_DEROGATORY\x10\x05\x12\x11\n" +
+	"\rMISC_FAMILIAR\x10\x06\x12\x18\n" +
+	"\x14MISC_FEMALE_LANGUAGE\x10\a\x12\x12\n" +
+	"\x0eMISC_HONORIFIC\x10\b\x12\x0f\n" +
+	"\vMISC_HUMBLE\x10\t\x12\x12\n" +
+	"\x0eMISC_IDIOMATIC\x10\n" +
+	"\x12\x10\n" +
+	"\fMISC_JOCULAR\x10\v\x12\x16\n" +
+	"\x12MISC_MALE_LANGUAGE\x10\f\x12\x0e\n" +
+	"\n" +
+	"MISC_MANGA\x10\r\x12\x11\n" +
+	"\rMISC_OBSOLETE\x10\x0e\x12\x10\n" +
+	"\fMISC_OBSCURE\x10\x0f\x12\x15\n" +
+	"\x11MISC_ONOMATOPOEIC\x10\x10\x12\x11\n" +
+	"\rMISC_POETICAL\x10\x11\x12\x0f\n" +
+	"\vMISC_POLITE\x10\x12\x12\x10\n" +
+	"\fMISC_PROVERB\x10\x13\x12\r\n" +
+	"\tMISC_RARE\x10\x14\x12\x12\n" +
+	"\x0eMISC_SENSITIVE\x10\x15\x12\x0e\n" +
+	"\n" +
+	"MISC_SLANG\x10\x16\x12\x13\n" +
+	"\x0fMISC_KANA_ALONE\x10\x17\x12\x0f\n" +
+	"\vMISC_VULGAR\x10\x18\x12\x10\n" +
+	"\fMISC_X_RATED\x10\x19*\xae\x02\n" +
+	"\aDialect\x12\x17\n" +
+	"\x13DIALECT_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14DIALECT_HOKKAIDO_BEN\x10\x01\x12\x16\n" +
+	"\x12DIALECT_KANSAI_BEN\x10\x02\x12\x16\n" +
+	"\x12DIALECT_KANTOU_BEN\x10\x03\x12\x15\n" +
+	"\x11DIALECT_KYOTO_BEN\x10\x04\x12\x18\n" +
+	"\x14DIALECT_KYUUSHUU_BEN\x10\x05\x12\x16\n" +
+	"\x12DIALECT_NAGANO_BEN\x10\x06\x12\x15\n" +
+	"\x11DIALECT_OSAKA_BEN\x10\a\x12\x18\n" +
+	"\x14DIALECT_RYUUKYUU_BEN\x10\b\x12\x17\n" +
+	"\x13DIALECT_TOUHOKU_BEN\x10\t\x12\x14\n" +
+	"\x10DIALECT_TOSA_BEN\x10\n" +
+	"\x12\x17\n" +
+	"\x13DIALECT_TSUGARU_BEN\x10\v*\xc6\x02\n" +
+	"\vOrthography\x12\x1b\n" +
+	"\x17ORTHOGRAPHY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11ORTHOGRAPHY_ATEJI\x10\x01\x12\x15\n" +
+	"\x11ORTHOGRAPHY_GIKUN\x10\x02\x12\x1f\n" +
+	"\x1bORTHOGRAPHY_IRREGULAR_KANJI\x10\x03\x12\x1e\n" +
+	"\x1aORTHOGRAPHY_IRREGULAR_KANA\x10\x04\x12#\n" +
+	"\x1fORTHOGRAPHY_IRREGULAR_OKURIGANA\x10\x05\x12\x1e\n" +
+	"\x1aORTHOGRAPHY_OUTDATED_KANJI\x10\x06\x12\x1d\n" +
+	"\x19ORTHOGRAPHY_OUTDATED_KANA\x10\a\x12*\n" +
+	"&ORTHOGRAPHY_OUTDATED_OR_IRREGULAR_KANA\x10\b\x12\x1b\n" +
+	"\x17ORTHOGRAPHY_KANJI_ALONE\x10\t2\x96\x02\n" +
+	"\x11DictionaryService\x128\n" +
+	"\bGetEntry\x12\x1a.jmdict.v1.GetEntryRequest\x1a\x10.jmdict.v1.Entry\x12=\n" +
+	"\x06Search\x12\x18.jmdict.v1.SearchRequest\x1a\x19.jmdict.v1.SearchResponse\x12F\n" +
+	"\tDeinflect\x12\x1b.jmdict.v1.DeinflectRequest\x1a\x1c.jmdict.v1.DeinflectResponse\x12@\n" +
+	"\vListEntries\x12\x1d.jmdict.v1.ListEntriesRequest\x1a\x10.jmdict.v1.Entry0\x01B/Z-github.com/0xfaded/jmdict/jmdictgrpc/jmdictpbb\x06proto3"
+
+var (
+	file_jmdict_proto_rawDescOnce sync.Once
+	file_jmdict_proto_rawDescData []byte
+)
+
+func file_jmdict_proto_rawDescGZIP() []byte {
+	file_jmdict_proto_rawDescOnce.Do(func() {
+		file_jmdict_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_jmdict_proto_rawDesc), len(file_jmdict_proto_rawDesc)))
+	})
+	return file_jmdict_proto_rawDescData
+}
+
+var file_jmdict_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_jmdict_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_jmdict_proto_goTypes = []any{
+	(SearchMode)(0),            // 0: jmdict.v1.SearchMode
+	(Position)(0),              // 1: jmdict.v1.Position
+	(Field)(0),                 // 2: jmdict.v1.Field
+	(Misc)(0),                  // 3: jmdict.v1.Misc
+	(Dialect)(0),               // 4: jmdict.v1.Dialect
+	(Orthography)(0),           // 5: jmdict.v1.Orthography
+	(*GetEntryRequest)(nil),    // 6: jmdict.v1.GetEntryRequest
+	(*SearchRequest)(nil),      // 7: jmdict.v1.SearchRequest
+	(*SearchResponse)(nil),     // 8: jmdict.v1.SearchResponse
+	(*Match)(nil),              // 9: jmdict.v1.Match
+	(*DeinflectRequest)(nil),   // 10: jmdict.v1.DeinflectRequest
+	(*DeinflectResponse)(nil),  // 11: jmdict.v1.DeinflectResponse
+	(*Deinflection)(nil),       // 12: jmdict.v1.Deinflection
+	(*ListEntriesRequest)(nil), // 13: jmdict.v1.ListEntriesRequest
+	(*Entry)(nil),              // 14: jmdict.v1.Entry
+	(*KEle)(nil),               // 15: jmdict.v1.KEle
+	(*REle)(nil),               // 16: jmdict.v1.REle
+	(*Info)(nil),               // 17: jmdict.v1.Info
+	(*Links)(nil),              // 18: jmdict.v1.Links
+	(*Bibl)(nil),               // 19: jmdict.v1.Bibl
+	(*Audit)(nil),              // 20: jmdict.v1.Audit
+	(*Sense)(nil),              // 21: jmdict.v1.Sense
+	(*LSource)(nil),            // 22: jmdict.v1.LSource
+	(*Gloss)(nil),              // 23: jmdict.v1.Gloss
+}
+var file_jmdict_proto_depIdxs = []int32{
+	0,  // 0: jmdict.v1.SearchRequest.mode:type_name -> jmdict.v1.SearchMode
+	9,  // 1: jmdict.v1.SearchResponse.matches:type_name -> jmdict.v1.Match
+	14, // 2: jmdict.v1.Match.entry:type_name -> jmdict.v1.Entry
+	12, // 3: jmdict.v1.DeinflectResponse.deinflections:type_name -> jmdict.v1.Deinflection
+	15, // 4: jmdict.v1.Entry.k_ele:type_name -> jmdict.v1.KEle
+	16, // 5: jmdict.v1.Entry.r_ele:type_name -> jmdict.v1.REle
+	17, // 6: jmdict.v1.Entry.info:type_name -> jmdict.v1.Info
+	21, // 7: jmdict.v1.Entry.sense:type_name -> jmdict.v1.Sense
+	5,  // 8: jmdict.v1.KEle.ke_inf:type_name -> jmdict.v1.Orthography
+	5,  // 9: jmdict.v1.REle.re_inf:type_name -> jmdict.v1.Orthography
+	18, // 10: jmdict.v1.Info.links:type_name -> jmdict.v1.Links
+	19, // 11: jmdict.v1.Info.bibl:type_name -> jmdict.v1.Bibl
+	20, // 12: jmdict.v1.Info.audit:type_name -> jmdict.v1.Audit
+	1,  // 13: jmdict.v1.Sense.pos:type_name -> jmdict.v1.Position
+	2,  // 14: jmdict.v1.Sense.field:type_name -> jmdict.v1.Field
+	3,  // 15: jmdict.v1.Sense.misc:type_name -> jmdict.v1.Misc
+	22, // 16: jmdict.v1.Sense.lsource:type_name -> jmdict.v1.LSource
+	4,  // 17: jmdict.v1.Sense.dial:type_name -> jmdict.v1.Dialect
+	23, // 18: jmdict.v1.Sense.gloss:type_name -> jmdict.v1.Gloss
+	6,  // 19: jmdict.v1.DictionaryService.GetEntry:input_type -> jmdict.v1.GetEntryRequest
+	7,  // 20: jmdict.v1.DictionaryService.Search:input_type -> jmdict.v1.SearchRequest
+	10, // 21: jmdict.v1.DictionaryService.Deinflect:input_type -> jmdict.v1.DeinflectRequest
+	13, // 22: jmdict.v1.DictionaryService.ListEntries:input_type -> jmdict.v1.ListEntriesRequest
+	14, // 23: jmdict.v1.DictionaryService.GetEntry:output_type -> jmdict.v1.Entry
+	8,  // 24: jmdict.v1.DictionaryService.Search:output_type -> jmdict.v1.SearchResponse
+	11, // 25: jmdict.v1.DictionaryService.Deinflect:output_type -> jmdict.v1.DeinflectResponse
+	14, // 26: jmdict.v1.DictionaryService.ListEntries:output_type -> jmdict.v1.Entry
+	23, // [23:27] is the sub-list for method output_type
+	19, // [19:23] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_jmdict_proto_init() }
+func file_jmdict_proto_init() {
+	if File_jmdict_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_jmdict_proto_rawDesc), len(file_jmdict_proto_rawDesc)),
+			NumEnums:      6,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_jmdict_proto_goTypes,
+		DependencyIndexes: file_jmdict_proto_depIdxs,
+		EnumInfos:         file_jmdict_proto_enumTypes,
+		MessageInfos:      file_jmdict_proto_msgTypes,
+	}.Build()
+	File_jmdict_proto = out.File
+	file_jmdict_proto_goTypes = nil
+	file_jmdict_proto_depIdxs = nil
+}
@@ -0,0 +1,268 @@
+// Protocol buffer definitions for dictionary lookups against JMdict.
+//
+// The messages mirror the types of package jmdict, and their fields are
+// named after the DTD elements, as in the JSON encoding. Entity codes are
+// given both as enums and, in the _code field which follows each enum
+// field, as the codes themselves. A code without an enum value, for
+// instance one added to JMdict since these enums were written, has the
+// UNSPECIFIED value and is only given in full by the _code field.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: jmdict.proto
+
+package jmdictpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DictionaryService_GetEntry_FullMethodName    = "/jmdict.v1.DictionaryService/GetEntry"
+	DictionaryService_Search_FullMethodName      = "/jmdict.v1.DictionaryService/Search"
+	DictionaryService_Deinflect_FullMethodName   = "/jmdict.v1.DictionaryService/Deinflect"
+	DictionaryService_ListEntries_FullMethodName = "/jmdict.v1.DictionaryService/ListEntries"
+)
+
+// DictionaryServiceClient is the client API for DictionaryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DictionaryService answers lookups against a dictionary held by the
+// server. Entries are ordered from most to least common.
+type DictionaryServiceClient interface {
+	// GetEntry returns the entry with the given sequence number, or fails
+	// with NOT_FOUND.
+	GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*Entry, error)
+	// Search returns a page of the entries matching a query.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// Deinflect returns the possible dictionary forms of an inflected
+	// word, with the entries each one leads to.
+	Deinflect(ctx context.Context, in *DeinflectRequest, opts ...grpc.CallOption) (*DeinflectResponse, error)
+	// ListEntries streams the entries of the dictionary in document order,
+	// optionally selected by a filter.
+	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Entry], error)
+}
+
+type dictionaryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDictionaryServiceClient(cc grpc.ClientConnInterface) DictionaryServiceClient {
+	return &dictionaryServiceClient{cc}
+}
+
+func (c *dictionaryServiceClient) GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*Entry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entry)
+	err := c.cc.Invoke(ctx, DictionaryService_GetEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, DictionaryService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) Deinflect(ctx context.Context, in *DeinflectRequest, opts ...grpc.CallOption) (*DeinflectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeinflectResponse)
+	err := c.cc.Invoke(ctx, DictionaryService_Deinflect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryServiceClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Entry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DictionaryService_ServiceDesc.Streams[0], DictionaryService_ListEntries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListEntriesRequest, Entry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DictionaryService_ListEntriesClient = grpc.ServerStreamingClient[Entry]
+
+// DictionaryServiceServer is the server API for DictionaryService service.
+// All implementations must embed UnimplementedDictionaryServiceServer
+// for forward compatibility.
+//
+// DictionaryService answers lookups against a dictionary held by the
+// server. Entries are ordered from most to least common.
+type DictionaryServiceServer interface {
+	// GetEntry returns the entry with the given sequence number, or fails
+	// with NOT_FOUND.
+	GetEntry(context.Context, *GetEntryRequest) (*Entry, error)
+	// Search returns a page of the entries matching a query.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// Deinflect returns the possible dictionary forms of an inflected
+	// word, with the entries each one leads to.
+	Deinflect(context.Context, *DeinflectRequest) (*DeinflectResponse, error)
+	// ListEntries streams the entries of the dictionary in document order,
+	// optionally selected by a filter.
+	ListEntries(*ListEntriesRequest, grpc.ServerStreamingServer[Entry]) error
+	mustEmbedUnimplementedDictionaryServiceServer()
+}
+
+// UnimplementedDictionaryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDictionaryServiceServer struct{}
+
+func (UnimplementedDictionaryServiceServer) GetEntry(context.Context, *GetEntryRequest) (*Entry, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetEntry not implemented")
+}
+func (UnimplementedDictionaryServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedDictionaryServiceServer) Deinflect(context.Context, *DeinflectRequest) (*DeinflectResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Deinflect not implemented")
+}
+func (UnimplementedDictionaryServiceServer) ListEntries(*ListEntriesRequest, grpc.ServerStreamingServer[Entry]) error {
+	return status.Error(codes.Unimplemented, "method ListEntries not implemented")
+}
+func (UnimplementedDictionaryServiceServer) mustEmbedUnimplementedDictionaryServiceServer() {}
+func (UnimplementedDictionaryServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeDictionaryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DictionaryServiceServer will
+// result in compilation errors.
+type UnsafeDictionaryServiceServer interface {
+	mustEmbedUnimplementedDictionaryServiceServer()
+}
+
+func RegisterDictionaryServiceServer(s grpc.ServiceRegistrar, srv DictionaryServiceServer) {
+	// If the following call panics, it indicates UnimplementedDictionaryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DictionaryService_ServiceDesc, srv)
+}
+
+func _DictionaryService_GetEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).GetEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_GetEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).GetEntry(ctx, req.(*GetEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_Deinflect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeinflectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServiceServer).Deinflect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DictionaryService_Deinflect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServiceServer).Deinflect(ctx, req.(*DeinflectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DictionaryService_ListEntries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListEntriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DictionaryServiceServer).ListEntries(m, &grpc.GenericServerStream[ListEntriesRequest, Entry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DictionaryService_ListEntriesServer = grpc.ServerStreamingServer[Entry]
+
+// DictionaryService_ServiceDesc is the grpc.ServiceDesc for DictionaryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DictionaryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jmdict.v1.DictionaryService",
+	HandlerType: (*DictionaryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEntry",
+			Handler:    _DictionaryService_GetEntry_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _DictionaryService_Search_Handler,
+		},
+		{
+			MethodName: "Deinflect",
+			Handler:    _DictionaryService_Deinflect_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListEntries",
+			Handler:       _DictionaryService_ListEntries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "jmdict.proto",
+}
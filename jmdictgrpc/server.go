@@ -0,0 +1,171 @@
+// Package jmdictgrpc implements the jmdict.v1 DictionaryService, defined
+// in jmdictpb/jmdict.proto, on top of a jmdict.Index.
+//
+// The service may be served in process, for instance in tests, with
+// bufconn:
+//
+//	lis := bufconn.Listen(1 << 20)
+//	s := grpc.NewServer()
+//	jmdictpb.RegisterDictionaryServiceServer(s, jmdictgrpc.NewServer(index))
+//	go s.Serve(lis)
+//	defer s.Stop()
+//
+//	conn, err := grpc.NewClient("passthrough:///bufnet",
+//		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+//			return lis.DialContext(ctx)
+//		}),
+//		grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	...
+//	client := jmdictpb.NewDictionaryServiceClient(conn)
+//	entry, err := client.GetEntry(ctx, &jmdictpb.GetEntryRequest{EntSeq: 1358280})
+package jmdictgrpc
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/0xfaded/jmdict"
+	"github.com/0xfaded/jmdict/jmdictgrpc/jmdictpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// A Server answers DictionaryService requests from an index. It is safe
+// for concurrent use.
+type Server struct {
+	jmdictpb.UnimplementedDictionaryServiceServer
+	index *jmdict.Index
+}
+
+// NewServer returns a server answering requests from index.
+func NewServer(index *jmdict.Index) *Server {
+	return &Server{index: index}
+}
+
+func (s *Server) GetEntry(ctx context.Context, req *jmdictpb.GetEntryRequest) (*jmdictpb.Entry, error) {
+	e, ok := s.index.Get(jmdict.EntSeq(req.EntSeq))
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no entry %d", req.EntSeq)
+	}
+	return EntryProto(e), nil
+}
+
+func (s *Server) Search(ctx context.Context, req *jmdictpb.SearchRequest) (*jmdictpb.SearchResponse, error) {
+	q := strings.TrimSpace(req.Query)
+	if q == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	offset, limit := int(req.Offset), int(req.Limit)
+	if offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+	if limit == 0 {
+		limit = defaultLimit
+	} else if limit < 0 || limit > maxLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxLimit)
+	}
+
+	matches, err := s.find(q, req.Mode, req.Deinflect)
+	if err != nil {
+		return nil, err
+	}
+	resp := &jmdictpb.SearchResponse{Total: int32(len(matches))}
+	if offset > len(matches) {
+		offset = len(matches)
+	}
+	end := offset + limit
+	if end > len(matches) {
+		end = len(matches)
+	}
+	for _, m := range matches[offset:end] {
+		pm := &jmdictpb.Match{Entry: EntryProto(m.Entry)}
+		if len(m.Reasons) > 0 {
+			pm.Deinflected = m.Word
+			pm.Inflections = m.Reasons
+		}
+		resp.Matches = append(resp.Matches, pm)
+	}
+	return resp, nil
+}
+
+// Returns the entries matching q in the given mode.
+func (s *Server) find(q string, mode jmdictpb.SearchMode, deinflect bool) ([]jmdict.Match, error) {
+	var entries []*jmdict.Entry
+	switch mode {
+	case jmdictpb.SearchMode_SEARCH_MODE_UNSPECIFIED, jmdictpb.SearchMode_SEARCH_MODE_EXACT:
+		if deinflect {
+			return s.index.Inflected(q), nil
+		}
+		entries = s.index.Exact(q)
+	case jmdictpb.SearchMode_SEARCH_MODE_ROMAJI:
+		if deinflect {
+			return s.index.Inflected(jmdict.RomajiToKana(q)), nil
+		}
+		entries = s.index.Romaji(q)
+	case jmdictpb.SearchMode_SEARCH_MODE_PREFIX:
+		entries = s.index.Prefix(q)
+	case jmdictpb.SearchMode_SEARCH_MODE_GLOSS:
+		entries = s.index.Gloss(q)
+	case jmdictpb.SearchMode_SEARCH_MODE_KANJI:
+		c, size := utf8.DecodeRuneInString(q)
+		if size != len(q) || c == utf8.RuneError {
+			return nil, status.Error(codes.InvalidArgument, "kanji search expects a single character")
+		}
+		entries = s.index.Kanji(c)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown search mode %v", mode)
+	}
+	matches := make([]jmdict.Match, len(entries))
+	for i, e := range entries {
+		matches[i] = jmdict.Match{Entry: e}
+	}
+	return matches, nil
+}
+
+func (s *Server) Deinflect(ctx context.Context, req *jmdictpb.DeinflectRequest) (*jmdictpb.DeinflectResponse, error) {
+	word := strings.TrimSpace(req.Word)
+	if word == "" {
+		return nil, status.Error(codes.InvalidArgument, "word is required")
+	}
+	resp := &jmdictpb.DeinflectResponse{}
+	for _, d := range jmdict.Deinflect(word) {
+		pd := &jmdictpb.Deinflection{Word: d.Word, Inflections: d.Reasons}
+		for _, e := range s.index.Exact(d.Word) {
+			if d.Matches(e) {
+				pd.EntSeq = append(pd.EntSeq, uint64(e.Id))
+			}
+		}
+		if req.MatchedOnly && len(pd.EntSeq) == 0 {
+			continue
+		}
+		resp.Deinflections = append(resp.Deinflections, pd)
+	}
+	return resp, nil
+}
+
+func (s *Server) ListEntries(req *jmdictpb.ListEntriesRequest, stream jmdictpb.DictionaryService_ListEntriesServer) error {
+	var keep jmdict.Filter
+	if req.Filter != "" {
+		f, err := jmdict.ParseFilter(req.Filter)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		keep = f
+	}
+	entries := s.index.Dict().Entries
+	for i := range entries {
+		if keep != nil && !keep(&entries[i]) {
+			continue
+		}
+		if err := stream.Send(EntryProto(&entries[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
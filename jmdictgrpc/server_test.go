@@ -0,0 +1,262 @@
+package jmdictgrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/0xfaded/jmdict"
+	"github.com/0xfaded/jmdict/jmdictgrpc/jmdictpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Serves the sample dictionary in process, adding an entry with a
+// part-of-speech code which has no enum value.
+func newClient(t *testing.T) jmdictpb.DictionaryServiceClient {
+	t.Helper()
+	dict, err := jmdict.Open("../testdata/sample.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dict.Entries = append(dict.Entries, jmdict.Entry{
+		Id:      1269130,
+		Kanji:   []jmdict.KEle{{Phrase: "呉れる"}},
+		Reading: []jmdict.REle{{Phrase: "くれる"}},
+		Sense: []jmdict.Sense{{
+			Position: []jmdict.Position{"v1-s", jmdict.VerbTransitive},
+			Misc:     []jmdict.Misc{jmdict.KanaAlone},
+			Gloss:    []string{"to give"},
+		}},
+	})
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	jmdictpb.RegisterDictionaryServiceServer(s, NewServer(jmdict.NewIndex(dict)))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return jmdictpb.NewDictionaryServiceClient(conn)
+}
+
+func wantCode(t *testing.T, what string, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Errorf("%s: got %v, want %v", what, err, code)
+	}
+}
+
+func TestGetEntry(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	e, err := client.GetEntry(ctx, &jmdictpb.GetEntryRequest{EntSeq: 1358280})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.EntSeq != 1358280 || len(e.KEle) != 2 || e.KEle[0].Keb != "食べる" {
+		t.Errorf("k_ele = %v", e.KEle)
+	}
+	if got := e.KEle[1].KeInf; len(got) != 1 || got[0] != jmdictpb.Orthography_ORTHOGRAPHY_IRREGULAR_KANJI {
+		t.Errorf("ke_inf = %v", got)
+	}
+	sense := e.Sense[0]
+	wantPos := []jmdictpb.Position{jmdictpb.Position_POSITION_VERB1, jmdictpb.Position_POSITION_VERB_TRANSITIVE}
+	if !reflect.DeepEqual(sense.Pos, wantPos) || !reflect.DeepEqual(sense.PosCode, []string{"v1", "vt"}) {
+		t.Errorf("pos = %v, pos_code = %q", sense.Pos, sense.PosCode)
+	}
+	if len(sense.Gloss) != 2 || sense.Gloss[1].Lang != "ger" || sense.Gloss[1].Text != "essen" {
+		t.Errorf("gloss = %v", sense.Gloss)
+	}
+
+	// Codes without an enum value are kept as codes.
+	e, err = client.GetEntry(ctx, &jmdictpb.GetEntryRequest{EntSeq: 1269130})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := e.Sense[0].Pos[0]; got != jmdictpb.Position_POSITION_UNSPECIFIED {
+		t.Errorf("pos of v1-s = %v, want UNSPECIFIED", got)
+	}
+	if got := e.Sense[0].PosCode; !reflect.DeepEqual(got, []string{"v1-s", "vt"}) {
+		t.Errorf("pos_code = %q, want [v1-s vt]", got)
+	}
+
+	_, err = client.GetEntry(ctx, &jmdictpb.GetEntryRequest{EntSeq: 1})
+	wantCode(t, "GetEntry(1)", err, codes.NotFound)
+}
+
+func TestSearch(t *testing.T) {
+	client := newClient(t)
+	tests := []struct {
+		req         *jmdictpb.SearchRequest
+		seqs        []uint64
+		inflections []string
+	}{
+		{&jmdictpb.SearchRequest{Query: "たべる"}, []uint64{1358280}, nil},
+		{&jmdictpb.SearchRequest{Query: "タベル", Mode: jmdictpb.SearchMode_SEARCH_MODE_EXACT}, []uint64{1358280}, nil},
+		{&jmdictpb.SearchRequest{Query: "食べた"}, nil, nil},
+		{&jmdictpb.SearchRequest{Query: "食べさせなかった", Deinflect: true},
+			[]uint64{1358280}, []string{"causative", "negative", "past"}},
+		{&jmdictpb.SearchRequest{Query: "た", Mode: jmdictpb.SearchMode_SEARCH_MODE_PREFIX},
+			[]uint64{1280640, 1358280}, nil},
+		{&jmdictpb.SearchRequest{Query: "Eat", Mode: jmdictpb.SearchMode_SEARCH_MODE_GLOSS}, []uint64{1358280}, nil},
+		{&jmdictpb.SearchRequest{Query: "kodomo", Mode: jmdictpb.SearchMode_SEARCH_MODE_ROMAJI}, []uint64{1591900}, nil},
+		{&jmdictpb.SearchRequest{Query: "ikanai", Mode: jmdictpb.SearchMode_SEARCH_MODE_ROMAJI, Deinflect: true},
+			[]uint64{1578850}, []string{"negative"}},
+		{&jmdictpb.SearchRequest{Query: "供", Mode: jmdictpb.SearchMode_SEARCH_MODE_KANJI}, []uint64{1591900}, nil},
+	}
+	for _, test := range tests {
+		resp, err := client.Search(context.Background(), test.req)
+		if err != nil {
+			t.Errorf("Search(%q, %v): %v", test.req.Query, test.req.Mode, err)
+			continue
+		}
+		var seqs []uint64
+		for _, m := range resp.Matches {
+			seqs = append(seqs, m.Entry.EntSeq)
+		}
+		if !reflect.DeepEqual(seqs, test.seqs) || int(resp.Total) != len(test.seqs) {
+			t.Errorf("Search(%q, %v) = %v of %d, want %v", test.req.Query, test.req.Mode, seqs, resp.Total, test.seqs)
+			continue
+		}
+		if len(seqs) > 0 && !reflect.DeepEqual(resp.Matches[0].Inflections, test.inflections) {
+			t.Errorf("Search(%q, %v): inflections %q, want %q",
+				test.req.Query, test.req.Mode, resp.Matches[0].Inflections, test.inflections)
+		}
+	}
+}
+
+func TestSearchPage(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+	resp, err := client.Search(ctx, &jmdictpb.SearchRequest{
+		Query: "た", Mode: jmdictpb.SearchMode_SEARCH_MODE_PREFIX, Offset: 1, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 2 || len(resp.Matches) != 1 || resp.Matches[0].Entry.EntSeq != 1358280 {
+		t.Errorf("page = %v", resp)
+	}
+	resp, err = client.Search(ctx, &jmdictpb.SearchRequest{
+		Query: "た", Mode: jmdictpb.SearchMode_SEARCH_MODE_PREFIX, Offset: 5})
+	if err != nil || resp.Total != 2 || len(resp.Matches) != 0 {
+		t.Errorf("page past the end = %v, %v", resp, err)
+	}
+
+	for _, req := range []*jmdictpb.SearchRequest{
+		{Query: " "},
+		{Query: "た", Offset: -1},
+		{Query: "た", Limit: -1},
+		{Query: "た", Limit: maxLimit + 1},
+		{Query: "食べ", Mode: jmdictpb.SearchMode_SEARCH_MODE_KANJI},
+		{Query: "た", Mode: 99},
+	} {
+		_, err := client.Search(ctx, req)
+		wantCode(t, "Search("+req.String()+")", err, codes.InvalidArgument)
+	}
+}
+
+func TestDeinflect(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+	resp, err := client.Deinflect(ctx, &jmdictpb.DeinflectRequest{Word: "食べなかった", MatchedOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Deinflections) != 1 {
+		t.Fatalf("deinflections = %v, want one", resp.Deinflections)
+	}
+	d := resp.Deinflections[0]
+	if d.Word != "食べる" || !reflect.DeepEqual(d.Inflections, []string{"negative", "past"}) ||
+		!reflect.DeepEqual(d.EntSeq, []uint64{1358280}) {
+		t.Errorf("deinflection = %v", d)
+	}
+
+	resp, err = client.Deinflect(ctx, &jmdictpb.DeinflectRequest{Word: "食べなかった"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Deinflections) < 2 || resp.Deinflections[0].Word != "食べなかった" {
+		t.Errorf("unmatched deinflections = %v", resp.Deinflections)
+	}
+
+	_, err = client.Deinflect(ctx, &jmdictpb.DeinflectRequest{})
+	wantCode(t, "Deinflect(\"\")", err, codes.InvalidArgument)
+}
+
+// Returns the sequence numbers of the entries streamed by ListEntries.
+func listEntries(t *testing.T, client jmdictpb.DictionaryServiceClient, filter string) ([]uint64, error) {
+	t.Helper()
+	stream, err := client.ListEntries(context.Background(), &jmdictpb.ListEntriesRequest{Filter: filter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seqs []uint64
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			return seqs, nil
+		}
+		if err != nil {
+			return seqs, err
+		}
+		seqs = append(seqs, e.EntSeq)
+	}
+}
+
+func TestListEntries(t *testing.T) {
+	client := newClient(t)
+	seqs, err := listEntries(t, client, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seqs) != 9 || seqs[0] != 1000220 || seqs[8] != 1269130 {
+		t.Errorf("entries = %v, want the 9 entries in document order", seqs)
+	}
+
+	seqs, err = listEntries(t, client, "pos:v5* -misc:arch")
+	if err != nil || !reflect.DeepEqual(seqs, []uint64{1578850}) {
+		t.Errorf("filtered entries = %v, %v; want [1578850]", seqs, err)
+	}
+
+	_, err = listEntries(t, client, "nonsense")
+	wantCode(t, "ListEntries(nonsense)", err, codes.InvalidArgument)
+}
+
+// The code tables must have an entry for every enum value.
+func TestEnumCodes(t *testing.T) {
+	for _, table := range []struct {
+		name  string
+		codes enumCodes
+		names map[int32]string
+	}{
+		{"Position", positionCodes, jmdictpb.Position_name},
+		{"Field", fieldCodes, jmdictpb.Field_name},
+		{"Misc", miscCodes, jmdictpb.Misc_name},
+		{"Dialect", dialectCodes, jmdictpb.Dialect_name},
+		{"Orthography", orthographyCodes, jmdictpb.Orthography_name},
+	} {
+		if len(table.codes) != len(table.names)-1 {
+			t.Errorf("%s: %d codes for %d enum values", table.name, len(table.codes), len(table.names)-1)
+		}
+		for code, v := range table.codes {
+			if _, ok := table.names[v]; !ok {
+				t.Errorf("%s: code %q has no enum value %d", table.name, code, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package jmdict
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// The JSON form of an entry uses the DTD element names as keys, omits
+// empty elements, and gives priorities as their raw values and glosses
+// with their languages.
+type jsonEntry struct {
+	Id      EntSeq        `json:"ent_seq"`
+	Kanji   []jsonKanji   `json:"k_ele,omitempty"`
+	Reading []jsonReading `json:"r_ele"`
+	Info    *jsonInfo     `json:"info,omitempty"`
+	Sense   []jsonSense   `json:"sense"`
+}
+
+type jsonKanji struct {
+	Phrase   Keb           `json:"keb"`
+	Info     []Orthography `json:"ke_inf,omitempty"`
+	Priority []string      `json:"ke_pri,omitempty"`
+}
+
+type jsonReading struct {
+	Phrase      Reb           `json:"reb"`
+	NoKanji     bool          `json:"re_nokanji,omitempty"`
+	Restrict    []ReRestr     `json:"re_restr,omitempty"`
+	Orthography []Orthography `json:"re_inf,omitempty"`
+	Priority    []string      `json:"re_pri,omitempty"`
+}
+
+type jsonInfo struct {
+	Links []jsonLink  `json:"links,omitempty"`
+	Bibl  []jsonBibl  `json:"bibl,omitempty"`
+	Etym  []string    `json:"etym,omitempty"`
+	Audit []jsonAudit `json:"audit,omitempty"`
+}
+
+type jsonLink struct {
+	Tag  string `json:"link_tag"`
+	Desc string `json:"link_desc"`
+	Uri  string `json:"link_uri"`
+}
+
+type jsonBibl struct {
+	Tag  string `json:"bib_tag,omitempty"`
+	Text string `json:"bib_txt,omitempty"`
+}
+
+type jsonAudit struct {
+	Date   string `json:"upd_date"`
+	Detail string `json:"upd_detl"`
+}
+
+type jsonSense struct {
+	KanjiRestrict   []string      `json:"stagk,omitempty"`
+	ReadingRestrict []string      `json:"stagr,omitempty"`
+	Position        []Position    `json:"pos,omitempty"`
+	Xref            []string      `json:"xref,omitempty"`
+	Antonym         []string      `json:"ant,omitempty"`
+	Field           []Field       `json:"field,omitempty"`
+	Misc            []Misc        `json:"misc,omitempty"`
+	Info            []string      `json:"s_inf,omitempty"`
+	LSource         []jsonLSource `json:"lsource,omitempty"`
+	Dialect         []Dialect     `json:"dial,omitempty"`
+	Gloss           []jsonGloss   `json:"gloss"`
+	Example         []string      `json:"example,omitempty"`
+}
+
+type jsonLSource struct {
+	Lang   string `json:"lang,omitempty"`
+	Type   string `json:"ls_type,omitempty"`
+	Wasei  string `json:"ls_wasei,omitempty"`
+	Source string `json:"text,omitempty"`
+}
+
+type jsonGloss struct {
+	Lang string `json:"lang"`
+	Text string `json:"text"`
+}
+
+// MarshalJSON encodes the entry using the DTD element names as keys, for
+// instance
+//
+//	{"ent_seq":1000220,"k_ele":[{"keb":"明白","ke_pri":["ichi1"]}],
+//	 "r_ele":[{"reb":"めいはく"}],
+//	 "sense":[{"pos":["adj-na"],"gloss":[{"lang":"eng","text":"obvious"}]}]}
+//
+// Empty elements are omitted, except for r_ele, sense and gloss, which
+// the DTD requires.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	j := jsonEntry{
+		Id:      e.Id,
+		Reading: make([]jsonReading, len(e.Reading)),
+		Sense:   make([]jsonSense, len(e.Sense)),
+	}
+	for _, k := range e.Kanji {
+		jk := jsonKanji{Phrase: k.Phrase, Info: k.Info}
+		for _, p := range k.Priority {
+			jk.Priority = append(jk.Priority, p.Raw)
+		}
+		j.Kanji = append(j.Kanji, jk)
+	}
+	for i, r := range e.Reading {
+		jr := jsonReading{Phrase: r.Phrase, NoKanji: r.ImproperReading != nil,
+			Restrict: r.Restrict, Orthography: r.Orthography}
+		for _, p := range r.Priority {
+			jr.Priority = append(jr.Priority, p.Raw)
+		}
+		j.Reading[i] = jr
+	}
+
+	info := e.Info
+	if len(info.Links)+len(info.Bibl)+len(info.Etym)+len(info.Audit) > 0 {
+		j.Info = &jsonInfo{Etym: info.Etym}
+		for _, l := range info.Links {
+			j.Info.Links = append(j.Info.Links, jsonLink{l.LinkTag, l.LinkDesc, l.LinkUri})
+		}
+		for _, b := range info.Bibl {
+			j.Info.Bibl = append(j.Info.Bibl, jsonBibl{b.BibTag, b.BibTxt})
+		}
+		for _, a := range info.Audit {
+			j.Info.Audit = append(j.Info.Audit, jsonAudit{a.UpdDate, a.UpdDetl})
+		}
+	}
+
+	for i, s := range e.Sense {
+		js := jsonSense{
+			KanjiRestrict:   s.KanjiRestrict,
+			ReadingRestrict: s.ReadingRestrict,
+			Position:        s.Position,
+			Xref:            s.Xref,
+			Antonym:         s.Antonym,
+			Field:           s.Field,
+			Misc:            s.Misc,
+			Info:            s.Info,
+			Dialect:         s.Dialect,
+			Gloss:           make([]jsonGloss, len(s.Gloss)),
+			Example:         s.Example,
+		}
+		for _, l := range s.LSource {
+			js.LSource = append(js.LSource, jsonLSource{l.Lang, l.Type, l.Wasei, l.Source})
+		}
+		for g, text := range s.Gloss {
+			js.Gloss[g] = jsonGloss{s.GlossLanguage(g), text}
+		}
+		j.Sense[i] = js
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes an entry encoded by MarshalJSON. The XMLName
+// fields are left empty.
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	var j jsonEntry
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*e = Entry{Id: j.Id}
+	for _, jk := range j.Kanji {
+		k := KEle{Phrase: jk.Phrase, Info: jk.Info}
+		for _, p := range jk.Priority {
+			k.Priority = append(k.Priority, KePriority{Priority: Priority{Raw: p}})
+		}
+		e.Kanji = append(e.Kanji, k)
+	}
+	for _, jr := range j.Reading {
+		r := REle{Phrase: jr.Phrase, Restrict: jr.Restrict, Orthography: jr.Orthography}
+		if jr.NoKanji {
+			r.ImproperReading = new(string)
+		}
+		for _, p := range jr.Priority {
+			r.Priority = append(r.Priority, RePriority{Priority: Priority{Raw: p}})
+		}
+		e.Reading = append(e.Reading, r)
+	}
+
+	if j.Info != nil {
+		e.Info.Etym = j.Info.Etym
+		for _, l := range j.Info.Links {
+			e.Info.Links = append(e.Info.Links, Links{LinkTag: l.Tag, LinkDesc: l.Desc, LinkUri: l.Uri})
+		}
+		for _, b := range j.Info.Bibl {
+			e.Info.Bibl = append(e.Info.Bibl, Bibl{BibTag: b.Tag, BibTxt: b.Text})
+		}
+		for _, a := range j.Info.Audit {
+			e.Info.Audit = append(e.Info.Audit, Audit{UpdDate: a.Date, UpdDetl: a.Detail})
+		}
+	}
+
+	for _, js := range j.Sense {
+		s := Sense{
+			KanjiRestrict:   js.KanjiRestrict,
+			ReadingRestrict: js.ReadingRestrict,
+			Position:        js.Position,
+			Xref:            js.Xref,
+			Antonym:         js.Antonym,
+			Field:           js.Field,
+			Misc:            js.Misc,
+			Info:            js.Info,
+			Dialect:         js.Dialect,
+			Example:         js.Example,
+		}
+		for _, l := range js.LSource {
+			s.LSource = append(s.LSource, LSource{Lang: l.Lang, Type: l.Type, Wasei: l.Wasei, Source: l.Source})
+		}
+		for _, g := range js.Gloss {
+			s.addGloss(g.Text, g.Lang)
+		}
+		e.Sense = append(e.Sense, s)
+	}
+	return nil
+}
+
+// WriteJSONL writes the dictionary as JSON lines, one entry per line in
+// the form given by Entry.MarshalJSON.
+func WriteJSONL(w io.Writer, dict JMDict) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	enc.SetEscapeHTML(false)
+	for i := range dict.Entries {
+		if err := enc.Encode(dict.Entries[i]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
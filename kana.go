@@ -0,0 +1,30 @@
+package jmdict
+
+// Reports whether r is a hiragana or katakana character, including the
+// chouon and the kana iteration marks.
+func isKana(r rune) bool {
+	return (r >= 0x3041 && r <= 0x309f) || (r >= 0x30a0 && r <= 0x30ff)
+}
+
+// Maps katakana to the equivalent hiragana, leaving other characters,
+// including the chouon, unchanged.
+func toHiragana(r rune) rune {
+	if r >= 0x30a1 && r <= 0x30f6 {
+		return r - 0x60
+	}
+	return r
+}
+
+// Reports whether two kana strings are equal once katakana is folded
+// to hiragana.
+func kanaEqual(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if toHiragana(a[i]) != toHiragana(b[i]) {
+			return false
+		}
+	}
+	return true
+}
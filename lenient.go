@@ -0,0 +1,141 @@
+package jmdict
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// A Problem is a defect in the input found by ReadLenient. Offset and
+// Line locate the defect in the input, and Entry holds the sequence
+// number of the affected entry when it could be determined.
+type Problem struct {
+	Offset  int64
+	Line    int
+	Entry   EntSeq
+	Err     error
+	Dropped bool // the entry was left out of the dictionary
+}
+
+func (p Problem) Error() string {
+	action := "kept"
+	if p.Dropped {
+		action = "dropped"
+	}
+	return fmt.Sprintf("jmdict: line %d (offset %d): entry %d %s: %v",
+		p.Line, p.Offset, p.Entry, action, p.Err)
+}
+
+var (
+	entityPattern = regexp.MustCompile(`&([A-Za-z_][A-Za-z0-9_.-]*);`)
+	entSeqPattern = regexp.MustCompile(`<ent_seq>\s*([0-9]+)\s*</ent_seq>`)
+)
+
+// Entities known to every XML document.
+var predefinedEntities = map[string]bool{
+	"lt": true, "gt": true, "amp": true, "apos": true, "quot": true,
+}
+
+// ReadLenient reads a dictionary like Read, but recovers from defects in
+// individual entries instead of failing. An unknown entity is kept as
+// its raw code, so that &foo; decodes to "foo", and the entry is kept.
+// An entry which is not well formed is dropped. Each defect is reported
+// as a Problem, in input order.
+//
+// The returned error is only non-nil if the input could not be read.
+func ReadLenient(r io.Reader) (JMDict, []Problem, error) {
+	dict := JMDict{XMLName: xml.Name{Local: "JMdict"}}
+	var problems []Problem
+	splitter := newEntrySplitter(r)
+	for {
+		chunk, err := splitter.next()
+		if err == io.EOF {
+			return dict, problems, nil
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return dict, problems, err
+		}
+		entry, found, ok := chunk.decodeLenient(err)
+		problems = append(problems, found...)
+		if ok {
+			dict.Entries = append(dict.Entries, entry)
+		}
+	}
+}
+
+// Decodes the chunk, repairing unknown entities. Reports whether the
+// entry could be decoded, along with the problems found. A non-nil
+// truncated error records that the chunk ended before </entry>.
+func (c entryChunk) decodeLenient(truncated error) (Entry, []Problem, bool) {
+	var problems []Problem
+	seq := c.seq()
+
+	var repaired map[string]string
+	for _, m := range entityPattern.FindAllSubmatchIndex(c.data, -1) {
+		name := string(c.data[m[2]:m[3]])
+		if _, ok := entities[name]; ok || predefinedEntities[name] || repaired[name] != "" {
+			continue
+		}
+		if repaired == nil {
+			repaired = make(map[string]string, len(entities)+1)
+			for k, v := range entities {
+				repaired[k] = v
+			}
+		}
+		repaired[name] = name
+		problems = append(problems, c.problem(seq, m[0],
+			fmt.Errorf("unknown entity &%s;", name), false))
+	}
+
+	if truncated != nil {
+		problems = append(problems, c.problem(seq, len(c.data), truncated, true))
+		return Entry{}, problems, false
+	}
+
+	decoder := newDecoder(bytes.NewReader(c.data))
+	if repaired != nil {
+		decoder.Entity = repaired
+	}
+	var entry Entry
+	if err := decoder.Decode(&entry); err != nil {
+		p := c.problem(seq, int(decoder.InputOffset()), err, true)
+		if syntax, ok := err.(*xml.SyntaxError); ok {
+			// The decoder counts lines from the start of the chunk.
+			p.Line = c.line + syntax.Line - 1
+			p.Err = fmt.Errorf("XML syntax error: %s", syntax.Msg)
+		}
+		problems = append(problems, p)
+		return Entry{}, problems, false
+	}
+	return entry, problems, true
+}
+
+// Returns the entry's sequence number, or zero if it has none.
+func (c entryChunk) seq() EntSeq {
+	m := entSeqPattern.FindSubmatch(c.data)
+	if m == nil {
+		return 0
+	}
+	n, err := strconv.ParseUint(string(m[1]), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return EntSeq(n)
+}
+
+// Builds a problem located at offset off within the chunk.
+func (c entryChunk) problem(seq EntSeq, off int, err error, dropped bool) Problem {
+	if off > len(c.data) {
+		off = len(c.data)
+	}
+	return Problem{
+		Offset:  c.offset + int64(off),
+		Line:    c.line + bytes.Count(c.data[:off], []byte{'\n'}),
+		Entry:   seq,
+		Err:     err,
+		Dropped: dropped,
+	}
+}
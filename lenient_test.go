@@ -0,0 +1,137 @@
+package jmdict
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// Returns the 1-based line of the byte at offset off of text.
+func lineAt(text string, off int) int {
+	return 1 + strings.Count(text[:off], "\n")
+}
+
+func TestReadLenient(t *testing.T) {
+	text := strings.Replace(sampleText(t), "&v1;", "&v9;", 1)
+	text = strings.Replace(text, "<keb>子供</keb>", "<keb>子供</reb>", 1)
+	dict, problems, err := ReadLenient(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []EntSeq{1000220, 1358280, 1080180, 1628530, 1578850, 1280640, 1512360}
+	if got := entrySeqs(dict.Entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("entries %v, want %v", got, want)
+	}
+	if pos := sampleEntry(t, dict, 1358280).Sense[0].Position; pos[0] != "v9" {
+		t.Errorf("repaired pos %q, want v9 first", pos)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("problems %v, want 2", problems)
+	}
+
+	entity := strings.Index(text, "&v9;")
+	if p := problems[0]; p.Entry != 1358280 || p.Dropped || p.Offset != int64(entity) ||
+		p.Line != lineAt(text, entity) || p.Err.Error() != "unknown entity &v9;" {
+		t.Errorf("entity problem %+v, want line %d offset %d", p, lineAt(text, entity), entity)
+	}
+
+	start := strings.Index(text, "<entry>\n<ent_seq>1591900")
+	end := start + strings.Index(text[start:], "</entry>")
+	tag := strings.Index(text, "子供</reb>")
+	if p := problems[1]; p.Entry != 1591900 || !p.Dropped || p.Line != lineAt(text, tag) ||
+		p.Offset < int64(start) || p.Offset > int64(end) || !strings.Contains(p.Err.Error(), "syntax error") {
+		t.Errorf("syntax problem %+v, want line %d within offsets %d-%d", p, lineAt(text, tag), start, end)
+	}
+	if got := problems[1].Error(); !strings.HasPrefix(got, "jmdict: line ") || !strings.Contains(got, "entry 1591900 dropped") {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestReadLenientTruncated(t *testing.T) {
+	text := sampleText(t)
+	text = text[:strings.Index(text, "<ent_seq>1512360")+40]
+	dict, problems, err := ReadLenient(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dict.Entries) != 7 {
+		t.Errorf("read %d entries, want the 7 complete ones", len(dict.Entries))
+	}
+	want := Problem{Offset: int64(len(text)), Line: lineAt(text, len(text)),
+		Entry: 1512360, Err: io.ErrUnexpectedEOF, Dropped: true}
+	if !reflect.DeepEqual(problems, []Problem{want}) {
+		t.Errorf("problems %+v, want %+v", problems, want)
+	}
+}
+
+func TestReadLenientError(t *testing.T) {
+	failure := errors.New("failed")
+	r := io.MultiReader(strings.NewReader(sampleText(t)[:1000]), iotest.ErrReader(failure))
+	if _, _, err := ReadLenient(r); err != failure {
+		t.Errorf("error %v, want %v", err, failure)
+	}
+}
+
+func TestEntrySplitter(t *testing.T) {
+	text := "<JMdict>\n<entry>a</entry>\n\n<entry>b\n<entry>c</entry><!-- x --><entry>d"
+	want := []struct {
+		data string
+		err  error
+	}{
+		{"<entry>a</entry>", nil},
+		{"<entry>b\n", nil},
+		{"<entry>c</entry>", nil},
+		{"<entry>d", io.ErrUnexpectedEOF},
+		{"", io.EOF},
+	}
+	for _, r := range []io.Reader{strings.NewReader(text), iotest.OneByteReader(strings.NewReader(text))} {
+		s := newEntrySplitter(r)
+		for i, w := range want {
+			chunk, err := s.next()
+			if string(chunk.data) != w.data || err != w.err {
+				t.Errorf("chunk %d: %q, %v, want %q, %v", i, chunk.data, err, w.data, w.err)
+				continue
+			}
+			if w.data == "" {
+				continue
+			}
+			off := strings.Index(text, w.data)
+			if chunk.offset != int64(off) || chunk.line != lineAt(text, off) {
+				t.Errorf("chunk %d at offset %d line %d, want %d line %d",
+					i, chunk.offset, chunk.line, off, lineAt(text, off))
+			}
+		}
+	}
+}
+
+// Entries far enough apart that the splitter must discard consumed input
+// keep their positions.
+func TestEntrySplitterLarge(t *testing.T) {
+	sample := sampleText(t)
+	first, last := strings.Index(sample, "<entry>"), strings.LastIndex(sample, "</JMdict>")
+	text := sample[:first] + strings.Repeat(sample[first:last], 200) + sample[last:]
+	s := newEntrySplitter(iotest.HalfReader(strings.NewReader(text)))
+	from, n := 0, 0
+	for {
+		chunk, err := s.next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		off := from + strings.Index(text[from:], "<entry>")
+		if chunk.offset != int64(off) || chunk.line != lineAt(text, off) {
+			t.Fatalf("entry %d at offset %d line %d, want %d line %d",
+				n, chunk.offset, chunk.line, off, lineAt(text, off))
+		}
+		from = off + len(chunk.data)
+		n++
+	}
+	if n != 1600 {
+		t.Errorf("split %d entries, want 1600", n)
+	}
+}
@@ -0,0 +1,162 @@
+package jmdict
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"runtime"
+)
+
+// Number of entries decoded by a worker at a time.
+const parallelBatchSize = 256
+
+// A parallelBatch is a run of consecutive entries handed to a worker.
+type parallelBatch struct {
+	chunks  []entryChunk
+	entries []Entry
+	err     error // first decoding error, or the error ending the input
+	done    chan struct{}
+}
+
+// ReadParallel reads a dictionary like Read, decoding entries on the
+// given number of goroutines. If workers is zero or less, GOMAXPROCS
+// goroutines are used. The input is split at <entry> boundaries and the
+// entries are reassembled in their original order, so the result is the
+// same as that of Read. Input which is not a JMdict document gives the
+// same error as it does from Read. After an error, ReadParallel stops
+// reading r and returns once the read in progress, if any, has finished.
+func ReadParallel(r io.Reader, workers int) (JMDict, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	root, splitter, err := splitRoot(r)
+	if err != nil {
+		return JMDict{}, err
+	}
+
+	work := make(chan *parallelBatch)
+	order := make(chan *parallelBatch, 2*workers)
+	stop := make(chan struct{})
+	exited := make(chan struct{})
+	defer func() {
+		close(stop)
+		<-exited
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for b := range work {
+				b.decode()
+			}
+		}()
+	}
+
+	go func() {
+		defer close(exited)
+		defer close(work)
+		defer close(order)
+		for {
+			b := &parallelBatch{done: make(chan struct{})}
+			var err error
+			for len(b.chunks) < parallelBatchSize {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				var chunk entryChunk
+				chunk, err = splitter.next()
+				if err != nil {
+					if err == io.ErrUnexpectedEOF {
+						line := chunk.line + bytes.Count(chunk.data, []byte{'\n'})
+						err = &xml.SyntaxError{Msg: "unexpected EOF", Line: line}
+					}
+					break
+				}
+				b.chunks = append(b.chunks, chunk)
+			}
+			if err != nil && err != io.EOF {
+				// Reported after the entries before it.
+				b.err = err
+			} else if len(b.chunks) == 0 {
+				return
+			}
+			select {
+			case order <- b:
+			case <-stop:
+				return
+			}
+			select {
+			case work <- b:
+			case <-stop:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	dict := JMDict{XMLName: root}
+	for b := range order {
+		<-b.done
+		dict.Entries = append(dict.Entries, b.entries...)
+		if b.err != nil {
+			return dict, b.err
+		}
+	}
+	return dict, nil
+}
+
+// Decodes the input up to the root element as Read does, and returns the
+// root and a splitter of the entries which follow it.
+func splitRoot(r io.Reader) (xml.Name, *entrySplitter, error) {
+	var prolog bytes.Buffer
+	d := newDecoder(io.TeeReader(r, &prolog))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return xml.Name{}, nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			if err := checkRoot(start); err != nil {
+				return xml.Name{}, nil, err
+			}
+			// The decoder reads ahead, so the input it has consumed
+			// past the root element is split first.
+			read := prolog.Bytes()
+			off := d.InputOffset()
+			s := newEntrySplitter(io.MultiReader(bytes.NewReader(read[off:]), r))
+			s.offset = off
+			s.line += bytes.Count(read[:off], []byte{'\n'})
+			return start.Name, s, nil
+		}
+	}
+}
+
+// Returns an error unless start is the root element of a JMdict
+// document.
+func checkRoot(start xml.StartElement) error {
+	if start.Name.Local != "JMdict" {
+		return xml.UnmarshalError("expected element type <JMdict> but have <" + start.Name.Local + ">")
+	}
+	return nil
+}
+
+// Decodes the batch's chunks, stopping at the first error.
+func (b *parallelBatch) decode() {
+	defer close(b.done)
+	b.entries = make([]Entry, 0, len(b.chunks))
+	for _, c := range b.chunks {
+		var entry Entry
+		if err := newDecoder(bytes.NewReader(c.data)).Decode(&entry); err != nil {
+			if syntax, ok := err.(*xml.SyntaxError); ok {
+				// Report the line within the whole input.
+				err = &xml.SyntaxError{Msg: syntax.Msg, Line: c.line + syntax.Line - 1}
+			}
+			b.err = err
+			return
+		}
+		b.entries = append(b.entries, entry)
+	}
+}
@@ -0,0 +1,92 @@
+package jmdict
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadParallel(t *testing.T) {
+	data, err := os.ReadFile("testdata/sample.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample := string(data)
+	doctype := strings.Replace(sample, "<JMdict>",
+		"<!DOCTYPE JMdict [\n<!ELEMENT JMdict (entry*)>\n]>\n<!-- comment -->\n<JMdict>", 1)
+	second := strings.Index(sample, "<entry>\n<ent_seq>1358280")
+	inputs := []struct {
+		name string
+		text string
+	}{
+		{"sample", sample},
+		{"doctype", doctype},
+		{"empty", ""},
+		{"text", "食べる [たべる] /(v1) to eat/\n"},
+		{"other root", "<?xml version=\"1.0\"?>\n<html><entry></entry></html>\n"},
+		{"truncated", sample[:second+40]},
+		{"mismatched tag", strings.Replace(sample, "<reb>たべる</reb>", "<reb>たべる</keb>", 1)},
+		{"mismatched tag after doctype", strings.Replace(doctype, "<reb>たべる</reb>", "<reb>たべる</keb>", 1)},
+		{"unknown entity", strings.Replace(sample, "&v1;", "&v9;", 1)},
+	}
+	for _, input := range inputs {
+		want, wantErr := Read(strings.NewReader(input.text))
+		for _, workers := range []int{1, 3} {
+			got, err := ReadParallel(strings.NewReader(input.text), workers)
+			if !reflect.DeepEqual(err, wantErr) {
+				t.Errorf("%s, %d workers: error %v, want %v", input.name, workers, err, wantErr)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s, %d workers: read %d entries, want %d as from Read",
+					input.name, workers, len(got.Entries), len(want.Entries))
+			}
+		}
+	}
+}
+
+// A reader which hands out its input a little at a time and counts the
+// reads made after done is set.
+type trickleReader struct {
+	r        io.Reader
+	done     atomic.Bool
+	lateRead atomic.Int32
+}
+
+func (r *trickleReader) Read(p []byte) (int, error) {
+	if r.done.Load() {
+		r.lateRead.Add(1)
+	}
+	return r.r.Read(p[:min(len(p), 64)])
+}
+
+func TestReadParallelStops(t *testing.T) {
+	data, err := os.ReadFile("testdata/sample.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample := string(data)
+	first := strings.Index(sample, "<entry>")
+	end := strings.LastIndex(sample, "</JMdict>")
+	entries := sample[first:end]
+	// A malformed first entry followed by enough entries to keep the
+	// producer busy.
+	text := sample[:first] + strings.Replace(entries, "</keb>", "</reb>", 1) +
+		strings.Repeat(entries, 1000) + sample[end:]
+
+	for _, workers := range []int{1, 3} {
+		r := &trickleReader{r: strings.NewReader(text)}
+		if _, err := ReadParallel(r, workers); err == nil {
+			t.Fatalf("%d workers: no error", workers)
+		}
+		r.done.Store(true)
+		// Give a producer left running time to show itself.
+		time.Sleep(10 * time.Millisecond)
+		if n := r.lateRead.Load(); n > 0 {
+			t.Errorf("%d workers: %d reads after returning", workers, n)
+		}
+	}
+}
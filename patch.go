@@ -0,0 +1,313 @@
+package jmdict
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// A Hash identifies the content of a dictionary. It depends only on the
+// entries, not on their order.
+type Hash [sha256.Size]byte
+
+func (h Hash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// ContentHash returns the hash of the entries of dict. Each entry is
+// encoded as in a binary snapshot, and the encodings are hashed in
+// EntSeq order. XML names and the Code and Rank of priorities are left
+// out, so that the hash does not depend on how the dictionary was
+// loaded.
+func ContentHash(dict JMDict) Hash {
+	order := make([]int, len(dict.Entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return dict.Entries[order[i]].Id < dict.Entries[order[j]].Id
+	})
+
+	h := sha256.New()
+	var rec bytes.Buffer
+	rw := bufio.NewWriter(&rec)
+	enc := binEncoder{index: make(map[string]uint64), content: true}
+	var tmp [binary.MaxVarintLen64]byte
+	for _, i := range order {
+		enc.reset()
+		enc.entry(&dict.Entries[i])
+		rec.Reset()
+		enc.writeTo(rw)
+		rw.Flush()
+		h.Write(tmp[:binary.PutUvarint(tmp[:], uint64(rec.Len()))])
+		h.Write(rec.Bytes())
+	}
+	var sum Hash
+	h.Sum(sum[:0])
+	return sum
+}
+
+// A Patch holds the changes needed to turn one release of the dictionary
+// into another. Added and modified entries are carried in full.
+type Patch struct {
+	Base   Hash // content hash of the dictionary the patch applies to
+	Result Hash // content hash of the dictionary after applying the patch
+	Upsert []Entry
+	Delete []EntSeq
+}
+
+var (
+	ErrPatchBase    = errors.New("jmdict: patch does not apply to this dictionary")
+	ErrPatchResult  = errors.New("jmdict: patched dictionary does not match patch hash")
+	ErrPatchFormat  = errors.New("jmdict: not a dictionary patch")
+	ErrPatchVersion = errors.New("jmdict: unsupported dictionary patch version")
+)
+
+// NewPatch returns a patch which turns old into new.
+func NewPatch(old, new JMDict) Patch {
+	return NewPatchFromDiff(Compare(old, new), ContentHash(old), ContentHash(new))
+}
+
+// NewPatchFromDiff returns a patch which applies diff, given the content
+// hashes of the dictionaries it was computed from.
+func NewPatchFromDiff(diff Diff, base, result Hash) Patch {
+	p := Patch{Base: base, Result: result}
+	p.Upsert = append(p.Upsert, diff.Added...)
+	for _, m := range diff.Modified {
+		p.Upsert = append(p.Upsert, m.New)
+	}
+	sort.Slice(p.Upsert, func(i, j int) bool { return p.Upsert[i].Id < p.Upsert[j].Id })
+	for _, e := range diff.Removed {
+		p.Delete = append(p.Delete, e.Id)
+	}
+	return p
+}
+
+// Apply returns the result of applying the patch to dict. dict is not
+// modified, so the update is atomic: either the returned dictionary is
+// complete and its content hash matches the patch, or an error is
+// returned. Modified entries keep their position and added entries are
+// inserted in EntSeq order.
+func (p Patch) Apply(dict JMDict) (JMDict, error) {
+	if ContentHash(dict) != p.Base {
+		return JMDict{}, ErrPatchBase
+	}
+
+	upsert := make(map[EntSeq]*Entry, len(p.Upsert))
+	for i := range p.Upsert {
+		upsert[p.Upsert[i].Id] = &p.Upsert[i]
+	}
+	deleted := make(map[EntSeq]bool, len(p.Delete))
+	for _, id := range p.Delete {
+		deleted[id] = true
+	}
+
+	var added []*Entry
+	existing := make(map[EntSeq]bool, len(dict.Entries))
+	for i := range dict.Entries {
+		existing[dict.Entries[i].Id] = true
+	}
+	for i := range p.Upsert {
+		if !existing[p.Upsert[i].Id] {
+			added = append(added, &p.Upsert[i])
+		}
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i].Id < added[j].Id })
+
+	result := JMDict{XMLName: dict.XMLName}
+	result.Entries = make([]Entry, 0, len(dict.Entries)+len(added))
+	for i := range dict.Entries {
+		e := &dict.Entries[i]
+		for len(added) > 0 && added[0].Id < e.Id {
+			result.Entries = append(result.Entries, *added[0])
+			added = added[1:]
+		}
+		if deleted[e.Id] {
+			continue
+		}
+		if u, ok := upsert[e.Id]; ok {
+			result.Entries = append(result.Entries, *u)
+		} else {
+			result.Entries = append(result.Entries, *e)
+		}
+	}
+	for _, e := range added {
+		result.Entries = append(result.Entries, *e)
+	}
+
+	if ContentHash(result) != p.Result {
+		return JMDict{}, ErrPatchResult
+	}
+	return result, nil
+}
+
+// Dict decodes every entry of the store.
+func (s *Store) Dict() (JMDict, error) {
+	dict := JMDict{Entries: make([]Entry, s.n)}
+	for i := range dict.Entries {
+		e, err := s.At(i)
+		if err != nil {
+			return JMDict{}, err
+		}
+		dict.Entries[i] = e
+	}
+	return dict, nil
+}
+
+// ApplyStoreFile applies the patch to the store file at path. The
+// patched store is written to a temporary file which replaces path only
+// once it is complete and verified, so readers see either the old or
+// the new dictionary. Processes which have the old store mapped keep
+// using it until they reopen the file.
+func (p Patch) ApplyStoreFile(path string) error {
+	s, err := OpenStore(path)
+	if err != nil {
+		return err
+	}
+	dict, err := s.Dict()
+	s.Close()
+	if err != nil {
+		return err
+	}
+	dict, err = p.Apply(dict)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	err = WriteStore(f, dict)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// The patch format is
+//
+//	magic "JMDP" | version uvarint | base hash | result hash |
+//	deletes uvarint | ent_seq uvarint... |
+//	upserts uvarint | (length uvarint | entry record)... | crc32
+//
+// where entry records are encoded as in the store format. Version 2
+// added the languages of glosses to the records. Patches of other
+// versions are rejected with ErrPatchVersion.
+const (
+	patchMagic   = "JMDP"
+	patchVersion = 2
+)
+
+// WritePatch writes p to w in a compact binary form.
+func WritePatch(w io.Writer, p Patch) error {
+	crc := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+	var tmp [binary.MaxVarintLen64]byte
+	uvarint := func(v uint64) {
+		bw.Write(tmp[:binary.PutUvarint(tmp[:], v)])
+	}
+
+	bw.WriteString(patchMagic)
+	uvarint(patchVersion)
+	bw.Write(p.Base[:])
+	bw.Write(p.Result[:])
+	uvarint(uint64(len(p.Delete)))
+	for _, id := range p.Delete {
+		uvarint(uint64(id))
+	}
+
+	uvarint(uint64(len(p.Upsert)))
+	var rec bytes.Buffer
+	rw := bufio.NewWriter(&rec)
+	enc := binEncoder{index: make(map[string]uint64)}
+	for i := range p.Upsert {
+		enc.reset()
+		enc.entry(&p.Upsert[i])
+		rec.Reset()
+		enc.writeTo(rw)
+		rw.Flush()
+		uvarint(uint64(rec.Len()))
+		bw.Write(rec.Bytes())
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.BigEndian, crc.Sum32())
+}
+
+// ReadPatch reads a patch written by WritePatch.
+func ReadPatch(r io.Reader) (Patch, error) {
+	var p Patch
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return p, err
+	}
+	if len(data) < len(patchMagic)+4 || string(data[:len(patchMagic)]) != patchMagic {
+		return p, ErrPatchFormat
+	}
+	body := data[:len(data)-4]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(data[len(body):]) {
+		return p, ErrPatchFormat
+	}
+
+	dec := binDecoder{data: body, off: len(patchMagic)}
+	if v := dec.uvarint(); v != patchVersion && dec.err == nil {
+		return p, fmt.Errorf("%w %d", ErrPatchVersion, v)
+	}
+	if len(body)-dec.off < 2*len(p.Base) {
+		return p, ErrPatchFormat
+	}
+	dec.off += copy(p.Base[:], body[dec.off:])
+	dec.off += copy(p.Result[:], body[dec.off:])
+
+	if n := dec.count(); n > 0 {
+		p.Delete = make([]EntSeq, n)
+		for i := range p.Delete {
+			p.Delete[i] = EntSeq(dec.uvarint())
+		}
+	}
+	if n := dec.count(); n > 0 {
+		p.Upsert = make([]Entry, n)
+		for i := range p.Upsert {
+			size := dec.count()
+			if dec.err != nil {
+				return Patch{}, dec.err
+			}
+			rec := binDecoder{data: body[dec.off : dec.off+size]}
+			rec.table()
+			rec.entry(&p.Upsert[i])
+			if rec.err == nil && rec.off != size {
+				rec.err = ErrPatchFormat
+			}
+			if rec.err != nil {
+				return Patch{}, rec.err
+			}
+			dec.off += size
+		}
+	}
+	if dec.err == nil && dec.off != len(body) {
+		dec.err = ErrPatchFormat
+	}
+	if dec.err != nil {
+		return Patch{}, dec.err
+	}
+	return p, nil
+}
@@ -0,0 +1,177 @@
+package jmdict
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// Each loader reads a dictionary back from its XML or from another
+// format it was written in.
+var hashLoaders = []struct {
+	name string
+	load func(d JMDict, data []byte) (JMDict, error)
+}{
+	{"XML", func(d JMDict, data []byte) (JMDict, error) {
+		return Read(bytes.NewReader(data))
+	}},
+	{"normalized XML", func(d JMDict, data []byte) (JMDict, error) {
+		return NewReader(bytes.NewReader(data), WithNormalize()).Read()
+	}},
+	{"ReadCompact", func(d JMDict, data []byte) (JMDict, error) {
+		return ReadCompact(bytes.NewReader(data))
+	}},
+	{"JSON", func(d JMDict, data []byte) (JMDict, error) {
+		var buf bytes.Buffer
+		if err := WriteJSONL(&buf, d); err != nil {
+			return JMDict{}, err
+		}
+		var loaded JMDict
+		dec := json.NewDecoder(&buf)
+		for {
+			var e Entry
+			if err := dec.Decode(&e); err == io.EOF {
+				return loaded, nil
+			} else if err != nil {
+				return loaded, err
+			}
+			loaded.Entries = append(loaded.Entries, e)
+		}
+	}},
+	{"binary", func(d JMDict, data []byte) (JMDict, error) {
+		var buf bytes.Buffer
+		if err := WriteBinary(&buf, d); err != nil {
+			return JMDict{}, err
+		}
+		return ReadBinary(&buf)
+	}},
+	{"EDICT", func(d JMDict, data []byte) (JMDict, error) {
+		var buf bytes.Buffer
+		if err := WriteEDICT2(&buf, d); err != nil {
+			return JMDict{}, err
+		}
+		return ReadEDICT2(&buf)
+	}},
+}
+
+func TestContentHashLoaders(t *testing.T) {
+	sample := readSample(t)
+	sampleXML, err := os.ReadFile("testdata/sample.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// EDICT2 records less than JMdict, so the loaders are also tried on
+	// the sample as read back from EDICT2, which every loader keeps
+	// whole. Its glosses are all English, so encoding/xml can write it.
+	edict, err := hashLoaders[len(hashLoaders)-1].load(sample, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edictXML, err := xml.Marshal(edict)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dict := range []struct {
+		name  string
+		dict  JMDict
+		data  []byte
+		edict bool
+	}{
+		{"sample", sample, sampleXML, false},
+		{"EDICT sample", edict, edictXML, true},
+	} {
+		want := ContentHash(dict.dict)
+		for _, loader := range hashLoaders {
+			if loader.name == "EDICT" && !dict.edict {
+				continue
+			}
+			loaded, err := loader.load(dict.dict, dict.data)
+			if err != nil {
+				t.Errorf("%s via %s: %v", dict.name, loader.name, err)
+				continue
+			}
+			if got := ContentHash(loaded); got != want {
+				t.Errorf("%s via %s: hash %v, want %v", dict.name, loader.name, got, want)
+			}
+		}
+	}
+}
+
+func TestContentHashChanges(t *testing.T) {
+	dict := readSample(t)
+	want := ContentHash(dict)
+
+	// Order does not matter.
+	dict.Entries[0], dict.Entries[1] = dict.Entries[1], dict.Entries[0]
+	if got := ContentHash(dict); got != want {
+		t.Errorf("hash changed with order: %v, want %v", got, want)
+	}
+
+	// Content does.
+	sampleEntry(t, dict, 1358280).Sense[0].Gloss[0] = "to devour"
+	if got := ContentHash(dict); got == want {
+		t.Error("hash unchanged after editing a gloss")
+	}
+}
+
+func TestPatch(t *testing.T) {
+	old, new := sampleRelease(t)
+	var buf bytes.Buffer
+	if err := WritePatch(&buf, NewPatch(old, new)); err != nil {
+		t.Fatal(err)
+	}
+	p, err := ReadPatch(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Base != ContentHash(old) || p.Result != ContentHash(new) ||
+		len(p.Upsert) != 2 || !reflect.DeepEqual(p.Delete, []EntSeq{1280640}) {
+		t.Fatalf("read patch %v -> %v, %d upserts, deletes %v", p.Base, p.Result, len(p.Upsert), p.Delete)
+	}
+
+	patched, err := p.Apply(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ContentHash(patched) != ContentHash(new) {
+		t.Error("patched dictionary differs from the new release")
+	}
+	if got := entrySeqs(patched.Entries); got[len(got)-1] != 2000010 {
+		t.Errorf("patched entries %v, want the added entry last", got)
+	}
+
+	if _, err := p.Apply(new); err != ErrPatchBase {
+		t.Errorf("applying to the new release: %v, want ErrPatchBase", err)
+	}
+	data := buf.Bytes()
+	data[len(data)-1] ^= 1
+	if _, err := ReadPatch(bytes.NewReader(data)); err != ErrPatchFormat {
+		t.Errorf("reading a corrupt patch: %v, want ErrPatchFormat", err)
+	}
+}
+
+// Patches written before gloss languages were recorded are rejected.
+func TestPatchVersion(t *testing.T) {
+	old, new := sampleRelease(t)
+	var buf bytes.Buffer
+	if err := WritePatch(&buf, NewPatch(old, new)); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	body := data[:len(data)-4]
+	for _, version := range []byte{1, patchVersion + 1} {
+		body[len(patchMagic)] = version
+		binary.BigEndian.PutUint32(data[len(body):], crc32.ChecksumIEEE(body))
+		if _, err := ReadPatch(bytes.NewReader(data)); !errors.Is(err, ErrPatchVersion) {
+			t.Errorf("version %d patch: %v, want ErrPatchVersion", version, err)
+		}
+	}
+}
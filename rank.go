@@ -0,0 +1,129 @@
+package jmdict
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+)
+
+// PriorityScore combines priorities into a single frequency score, where
+// a higher score means a more common word. The score is the sum of
+//
+//   - 50 for each of news1, ichi1, spec1 and gai1
+//   - 20 for each of news2, ichi2, spec2 and gai2
+//   - 49-xx for nfxx, so nf01 scores 48 and nf48 scores 1
+//
+// A word in the top 500 of the wordfreq file and in both news1 and ichi1
+// therefore scores 148, and a word with no priorities scores 0.
+func PriorityScore(pris []Priority) int {
+	score := 0
+	for _, p := range pris {
+		p = p.Parse()
+		switch p.Code {
+		case Newspaper, BunruiShuu, Special, LoanWord:
+			if p.Rank == 1 {
+				score += 50
+			} else if p.Rank == 2 {
+				score += 20
+			}
+		case Frequency:
+			if p.Rank > 0 && p.Rank < 49 {
+				score += 49 - p.Rank
+			}
+		}
+	}
+	return score
+}
+
+// Score added to forms found in a frequency list, placing them above
+// every form which is ranked by priorities alone.
+const frequencyListScore = 1 << 24
+
+// A Ranker orders forms and entries by how common they are. The zero
+// value ranks by PriorityScore alone. A frequency list loaded with
+// LoadFrequencyList overrides the priorities for the forms it contains.
+type Ranker struct {
+	freq map[string]int
+}
+
+// LoadFrequencyList reads a word frequency list, such as one derived from
+// a corpus, with one word per line in order of decreasing frequency. The
+// word is the first tab separated column and other columns are ignored,
+// as are blank lines and lines starting with "#". When a word appears
+// more than once its first position is used.
+func (r *Ranker) LoadFrequencyList(in io.Reader) error {
+	if r.freq == nil {
+		r.freq = make(map[string]int)
+	}
+	scanner := bufio.NewScanner(in)
+	pos := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word := strings.TrimSpace(strings.SplitN(line, "\t", 2)[0])
+		if word == "" {
+			continue
+		}
+		pos++
+		if _, ok := r.freq[word]; !ok {
+			r.freq[word] = pos
+		}
+	}
+	return scanner.Err()
+}
+
+// PairScore scores a kanji/reading pair. If the frequency list contains
+// the pair's headword, the score is frequencyListScore less its position
+// in the list. Otherwise it is the PriorityScore of the priorities
+// recorded against the pair.
+func (r *Ranker) PairScore(p Pair) int {
+	if pos, ok := r.freq[p.Headword()]; ok {
+		return frequencyListScore - pos
+	}
+	return PriorityScore(p.Priorities())
+}
+
+// Best returns the highest scoring pair of the entry, and its score.
+// Ties are resolved in favour of a pair whose kanji carries a priority,
+// and then of the earlier pair, so the form which actually carries a
+// priority is preferred over its unmarked variants.
+func (r *Ranker) Best(e *Entry) (Pair, int) {
+	var best Pair
+	bestScore := -1
+	for _, p := range e.Pairs() {
+		score := r.PairScore(p)
+		if score > bestScore || score == bestScore && !best.marked() && p.marked() {
+			best, bestScore = p, score
+		}
+	}
+	if bestScore < 0 {
+		bestScore = 0
+	}
+	return best, bestScore
+}
+
+// Reports whether the pair's kanji carries a priority.
+func (p Pair) marked() bool {
+	return p.Kanji != nil && len(p.Kanji.Priority) > 0
+}
+
+// EntryScore scores an entry by its best pair.
+func (r *Ranker) EntryScore(e *Entry) int {
+	_, score := r.Best(e)
+	return score
+}
+
+// Sort orders entries from most to least common. Entries with equal
+// scores keep their relative order.
+func (r *Ranker) Sort(entries []Entry) {
+	scores := make(map[EntSeq]int, len(entries))
+	for i := range entries {
+		scores[entries[i].Id] = r.EntryScore(&entries[i])
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return scores[entries[i].Id] > scores[entries[j].Id]
+	})
+}
@@ -0,0 +1,94 @@
+package jmdict
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPriorityScore(t *testing.T) {
+	tests := []struct {
+		raw   []string
+		score int
+	}{
+		{nil, 0},
+		{[]string{"news1", "ichi1", "nf01"}, 148},
+		{[]string{"spec2", "gai1"}, 70},
+		{[]string{"nf48"}, 1},
+		{[]string{"nf49", "news3"}, 0},
+	}
+	for _, test := range tests {
+		var pris []Priority
+		for _, raw := range test.raw {
+			pris = append(pris, Priority{Raw: raw})
+		}
+		if got := PriorityScore(pris); got != test.score {
+			t.Errorf("PriorityScore(%q) = %d, want %d", test.raw, got, test.score)
+		}
+	}
+}
+
+func TestRankerBest(t *testing.T) {
+	dict := readSample(t)
+	var r Ranker
+	tests := []struct {
+		seq   EntSeq
+		head  string
+		score int
+	}{
+		// The priorities of the usually kana これ are recorded against
+		// the reading only.
+		{1628530, "此れ", 148},
+		{1358280, "食べる", 138},
+		{1080180, "倫敦", 50},
+	}
+	for _, test := range tests {
+		best, score := r.Best(sampleEntry(t, dict, test.seq))
+		if best.Headword() != test.head || score != test.score {
+			t.Errorf("Best(%d) = %s, %d; want %s, %d", test.seq, best.Headword(), score, test.head, test.score)
+		}
+	}
+
+	// The irregular 喰べる has no priorities of its own, and does not
+	// take those of たべる when it comes first.
+	e := sampleEntry(t, dict, 1358280)
+	e.Kanji[0], e.Kanji[1] = e.Kanji[1], e.Kanji[0]
+	pairs := e.Pairs()
+	if got := PriorityScore(pairs[0].Priorities()); got != 0 {
+		t.Errorf("%s scores %d, want 0", pairs[0], got)
+	}
+	if best, score := r.Best(e); best.Headword() != "食べる" || score != 138 {
+		t.Errorf("Best with 喰べる first = %s, %d; want 食べる, 138", best.Headword(), score)
+	}
+
+	// When the reading does not carry the kanji's priority, both forms
+	// score 0, and the one carrying the priority still wins.
+	for i := range e.Kanji {
+		if e.Kanji[i].Phrase == "食べる" {
+			e.Kanji[i].Priority = []KePriority{{Priority: Priority{Raw: "spec1"}}}
+		}
+	}
+	if best, score := r.Best(e); best.Headword() != "食べる" || score != 0 {
+		t.Errorf("Best with an unmatched ke_pri = %s, %d; want 食べる, 0", best.Headword(), score)
+	}
+}
+
+func TestRankerSort(t *testing.T) {
+	var r Ranker
+	dict := readSample(t)
+	r.Sort(dict.Entries)
+	want := []EntSeq{1628530, 1280640, 1578850, 1512360, 1591900, 1000220, 1358280, 1080180}
+	if got := entrySeqs(dict.Entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted = %v, want %v", got, want)
+	}
+
+	// A frequency list places its words above all others.
+	if err := r.LoadFrequencyList(strings.NewReader("# corpus\n食べる\t1200\n\n倫敦\n食べる\n")); err != nil {
+		t.Fatal(err)
+	}
+	r.Sort(dict.Entries)
+	want = []EntSeq{1358280, 1080180, 1628530, 1280640, 1578850, 1512360, 1591900, 1000220}
+	if got := entrySeqs(dict.Entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted with frequency list = %v, want %v", got, want)
+	}
+}
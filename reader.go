@@ -0,0 +1,343 @@
+package jmdict
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// Number of entries between calls to the progress function.
+const progressInterval = 1000
+
+// An Option configures a Reader.
+type Option func(*Reader)
+
+// WithLanguages keeps only glosses in the given languages, ISO 639-2
+// codes such as "eng" or "ger". Other glosses are skipped as the input
+// is read, without being decoded. Senses left without glosses are
+// dropped, as are entries left without senses. Sense numbers therefore
+// change, which affects xref and ant elements that give one.
+func WithLanguages(langs ...string) Option {
+	return func(rd *Reader) {
+		rd.langs = make(map[string]bool, len(langs))
+		for _, l := range langs {
+			rd.langs[l] = true
+		}
+	}
+}
+
+// WithStrictEntities selects how entity references which are not
+// defined are handled. When strict, the default, they are an error. When
+// not strict, the reference is kept as its raw code, so that "&foo;"
+// decodes to "foo", and the decoder also accepts the other minor syntax
+// errors allowed by xml.Decoder when Strict is false.
+func WithStrictEntities(strict bool) Option {
+	return func(rd *Reader) {
+		rd.strict = strict
+	}
+}
+
+// WithEntities defines additional entities, mapping each name to its
+// replacement text. Entities defined by the library are overridden.
+func WithEntities(entities map[string]string) Option {
+	return func(rd *Reader) {
+		for k, v := range entities {
+			rd.entities[k] = v
+		}
+	}
+}
+
+// WithNormalize normalizes each entry as it is decoded: surrounding
+// white space is trimmed from kebs, rebs and glosses, and the Code and
+// Rank of every priority are filled in.
+func WithNormalize() Option {
+	return func(rd *Reader) {
+		rd.normalize = true
+	}
+}
+
+// WithProgress calls fn with the number of entries read so far, every
+// thousand entries and once the input has been read.
+func WithProgress(fn func(n int)) Option {
+	return func(rd *Reader) {
+		rd.progress = fn
+	}
+}
+
+// Progress records how far a Reader has got through its input.
+type Progress struct {
+	Entries int   // entries returned
+	Bytes   int64 // bytes of input consumed
+}
+
+// WithProgressReport calls fn with the progress made, at most once per
+// interval and once the input has been read.
+func WithProgressReport(fn func(Progress), interval time.Duration) Option {
+	return func(rd *Reader) {
+		rd.report, rd.interval = fn, interval
+	}
+}
+
+// WithContext stops the Reader once ctx is done. Reading then fails with
+// the context's error.
+func WithContext(ctx context.Context) Option {
+	return func(rd *Reader) {
+		rd.ctx = ctx
+	}
+}
+
+// WithFilter keeps only entries matching f. The filter sees each entry
+// after any other processing.
+func WithFilter(f Filter) Option {
+	return func(rd *Reader) {
+		rd.filter = f
+	}
+}
+
+// WithInterner compacts each entry with in as it is decoded, see
+// Entry.Compact. Read also reallocates its slice of entries to fit.
+func WithInterner(in *Interner) Option {
+	return func(rd *Reader) {
+		rd.interner = in
+	}
+}
+
+// A Reader decodes a JMdict document entry by entry.
+type Reader struct {
+	input   *xml.Decoder // decoder of the raw input
+	decoder *xml.Decoder // decoder of the filtered input
+	root    xml.Name
+	started bool
+	done    bool
+	count   int
+
+	entities  map[string]string
+	strict    bool
+	langs     map[string]bool
+	normalize bool
+	progress  func(n int)
+	filter    Filter
+	interner  *Interner
+
+	ctx      context.Context
+	report   func(Progress)
+	interval time.Duration
+	reported time.Time
+}
+
+// NewReader returns a Reader decoding r, configured by opts.
+func NewReader(r io.Reader, opts ...Option) *Reader {
+	rd := &Reader{
+		entities: make(map[string]string, len(entities)),
+		strict:   true,
+	}
+	for k, v := range entities {
+		rd.entities[k] = v
+	}
+	for _, opt := range opts {
+		opt(rd)
+	}
+	if rd.ctx != nil {
+		r = contextReader{rd.ctx, r}
+	}
+	rd.input = xml.NewDecoder(r)
+	rd.input.Entity = rd.entities
+	rd.input.Strict = rd.strict
+	rd.decoder = rd.input
+	if rd.langs != nil {
+		rd.decoder = xml.NewTokenDecoder(glossFilter{rd.input, rd.langs})
+		rd.decoder.Strict = rd.strict
+	}
+	return rd
+}
+
+// Next returns the next entry, or io.EOF once the root element has been
+// read.
+func (rd *Reader) Next() (Entry, error) {
+	for !rd.done {
+		if rd.ctx != nil {
+			if err := rd.ctx.Err(); err != nil {
+				return Entry{}, err
+			}
+		}
+		tok, err := rd.decoder.Token()
+		if err != nil {
+			return Entry{}, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if !rd.started {
+				if err := checkRoot(tok); err != nil {
+					return Entry{}, err
+				}
+				rd.root, rd.started = tok.Name, true
+				continue
+			}
+			if tok.Name.Local != "entry" {
+				if err := rd.decoder.Skip(); err != nil {
+					return Entry{}, err
+				}
+				continue
+			}
+			var entry Entry
+			if err := rd.decoder.DecodeElement(&entry, &tok); err != nil {
+				return Entry{}, err
+			}
+			keep := rd.process(&entry)
+			if keep {
+				rd.count++
+			}
+			if rd.report != nil && time.Since(rd.reported) >= rd.interval {
+				rd.reported = time.Now()
+				rd.report(rd.Progress())
+			}
+			if !keep {
+				continue
+			}
+			if rd.progress != nil && rd.count%progressInterval == 0 {
+				rd.progress(rd.count)
+			}
+			return entry, nil
+		case xml.EndElement:
+			rd.done = true
+			if rd.progress != nil && rd.count%progressInterval != 0 {
+				rd.progress(rd.count)
+			}
+			if rd.report != nil {
+				rd.report(rd.Progress())
+			}
+		}
+	}
+	return Entry{}, io.EOF
+}
+
+// Progress returns the progress made so far.
+func (rd *Reader) Progress() Progress {
+	return Progress{Entries: rd.count, Bytes: rd.input.InputOffset()}
+}
+
+// Read reads the remaining entries into a dictionary.
+func (rd *Reader) Read() (JMDict, error) {
+	var dict JMDict
+	var err error
+	for {
+		var entry Entry
+		if entry, err = rd.Next(); err != nil {
+			break
+		}
+		dict.Entries = append(dict.Entries, entry)
+	}
+	dict.XMLName = rd.root
+	if rd.interner != nil {
+		dict.Entries = fit(dict.Entries)
+	}
+	if err != io.EOF || !rd.started {
+		return dict, err
+	}
+	return dict, nil
+}
+
+// ReadContext reads a dictionary like Read, configured by opts, and
+// stops with the context's error once ctx is done. Use NewReader with
+// WithContext to read entries one at a time.
+func ReadContext(ctx context.Context, r io.Reader, opts ...Option) (JMDict, error) {
+	return NewReader(r, append([]Option{WithContext(ctx)}, opts...)...).Read()
+}
+
+// A contextReader fails reads once its context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+// Applies the configured processing to the entry, and reports whether
+// it should be kept.
+func (rd *Reader) process(e *Entry) bool {
+	if !rd.strict {
+		e.rawEntities()
+	}
+	if rd.langs != nil {
+		if e.dropEmptySenses(); len(e.Sense) == 0 {
+			return false
+		}
+	}
+	if rd.normalize {
+		e.normalize()
+	}
+	if rd.interner != nil {
+		e.Compact(rd.interner)
+	}
+	return rd.filter == nil || rd.filter(e)
+}
+
+// Replaces entity references left in place by a non-strict decoder
+// with their codes.
+func (e *Entry) rawEntities() {
+	raw := func(s string) string {
+		if len(s) > 2 && s[0] == '&' && s[len(s)-1] == ';' {
+			return s[1 : len(s)-1]
+		}
+		return s
+	}
+	for i := range e.Kanji {
+		for j, o := range e.Kanji[i].Info {
+			e.Kanji[i].Info[j] = Orthography(raw(string(o)))
+		}
+	}
+	for i := range e.Reading {
+		for j, o := range e.Reading[i].Orthography {
+			e.Reading[i].Orthography[j] = Orthography(raw(string(o)))
+		}
+	}
+	for i := range e.Sense {
+		s := &e.Sense[i]
+		for j, p := range s.Position {
+			s.Position[j] = Position(raw(string(p)))
+		}
+		for j, f := range s.Field {
+			s.Field[j] = Field(raw(string(f)))
+		}
+		for j, m := range s.Misc {
+			s.Misc[j] = Misc(raw(string(m)))
+		}
+		for j, d := range s.Dialect {
+			s.Dialect[j] = Dialect(raw(string(d)))
+		}
+	}
+}
+
+func (e *Entry) normalize() {
+	for i := range e.Kanji {
+		k := &e.Kanji[i]
+		k.Phrase = Keb(strings.TrimSpace(string(k.Phrase)))
+		for j := range k.Priority {
+			k.Priority[j].Priority = normalizePriority(k.Priority[j].Priority)
+		}
+	}
+	for i := range e.Reading {
+		r := &e.Reading[i]
+		r.Phrase = Reb(strings.TrimSpace(string(r.Phrase)))
+		for j := range r.Priority {
+			r.Priority[j].Priority = normalizePriority(r.Priority[j].Priority)
+		}
+	}
+	for i := range e.Sense {
+		for j, g := range e.Sense[i].Gloss {
+			e.Sense[i].Gloss[j] = strings.TrimSpace(g)
+		}
+	}
+}
+
+func normalizePriority(p Priority) Priority {
+	p.Raw = strings.TrimSpace(p.Raw)
+	return p.Parse()
+}
@@ -0,0 +1,271 @@
+package jmdict
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReaderLanguages(t *testing.T) {
+	dict, err := NewReader(strings.NewReader(sampleText(t)), WithLanguages("ger")).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := entrySeqs(dict.Entries); !reflect.DeepEqual(got, []EntSeq{1358280, 1628530}) {
+		t.Fatalf("entries %v, want [1358280 1628530]", got)
+	}
+	for i, want := range []string{"essen", "dies"} {
+		senses := dict.Entries[i].Sense
+		if len(senses) != 1 || !reflect.DeepEqual(senses[0].Gloss, []string{want}) ||
+			!reflect.DeepEqual(senses[0].GlossLang, []string{"ger"}) {
+			t.Errorf("%d: senses %+v, want the gloss %q", dict.Entries[i].Id, senses, want)
+		}
+	}
+
+	dict, err = NewReader(strings.NewReader(sampleText(t)), WithLanguages("eng", "fre")).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := sampleEntry(t, dict, 1512360)
+	if len(e.Sense) != 2 || !reflect.DeepEqual(e.Sense[1].GlossLang, []string{"eng", "fre"}) {
+		t.Errorf("1512360: senses %+v, want eng and fre glosses", e.Sense)
+	}
+	if e := sampleEntry(t, dict, 1358280); !reflect.DeepEqual(e.Sense[0].Gloss, []string{"to eat"}) {
+		t.Errorf("1358280: glosses %q, want [to eat]", e.Sense[0].Gloss)
+	}
+}
+
+func TestReaderEntities(t *testing.T) {
+	text := strings.Replace(sampleText(t), "&v1;", "&v9;", 1)
+	if _, err := NewReader(strings.NewReader(text)).Read(); err == nil {
+		t.Error("unknown entity accepted when strict")
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []Position
+	}{
+		{"lenient", []Option{WithStrictEntities(false)}, []Position{"v9", "vt"}},
+		{"defined", []Option{WithEntities(map[string]string{"v9": "v9"})}, []Position{"v9", "vt"}},
+		{"overridden", []Option{WithEntities(map[string]string{"v9": "v9", "vt": "transitive"})},
+			[]Position{"v9", "transitive"}},
+	}
+	for _, test := range tests {
+		dict, err := NewReader(strings.NewReader(text), test.opts...).Read()
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if len(dict.Entries) != 8 {
+			t.Errorf("%s: read %d entries, want 8", test.name, len(dict.Entries))
+		}
+		if pos := sampleEntry(t, dict, 1358280).Sense[0].Position; !reflect.DeepEqual(pos, test.want) {
+			t.Errorf("%s: pos %q, want %q", test.name, pos, test.want)
+		}
+	}
+}
+
+func TestReaderNormalize(t *testing.T) {
+	text := strings.Replace(sampleText(t), "<reb>たべる</reb>", "<reb> たべる\n</reb>", 1)
+	text = strings.Replace(text, "<gloss>to eat</gloss>", "<gloss>\n\tto eat </gloss>", 1)
+	dict, err := NewReader(strings.NewReader(text), WithNormalize()).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := sampleEntry(t, dict, 1358280)
+	if e.Reading[0].Phrase != "たべる" || e.Sense[0].Gloss[0] != "to eat" {
+		t.Errorf("reading %q and gloss %q not trimmed", e.Reading[0].Phrase, e.Sense[0].Gloss[0])
+	}
+	want := Priority{Raw: "ichi1"}.Parse()
+	if got := e.Kanji[0].Priority[0].Priority; got != want || got.Code == "" {
+		t.Errorf("priority %+v, want %+v", got, want)
+	}
+}
+
+func TestReaderProgress(t *testing.T) {
+	data := repeatedSample(t, 313)
+	var calls []int
+	dict, err := NewReader(strings.NewReader(string(data)), WithProgress(func(n int) {
+		calls = append(calls, n)
+	})).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1000, 2000, 2504}; len(dict.Entries) != 2504 || !reflect.DeepEqual(calls, want) {
+		t.Errorf("read %d entries with progress %v, want %v", len(dict.Entries), calls, want)
+	}
+
+	// Only entries kept by the filter count.
+	calls = nil
+	dict, err = NewReader(strings.NewReader(string(data)),
+		WithFilter(func(e *Entry) bool { return e.Id == 1578850 }),
+		WithProgress(func(n int) { calls = append(calls, n) })).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dict.Entries) != 313 || !reflect.DeepEqual(calls, []int{313}) {
+		t.Errorf("read %d entries with progress %v, want 313 and [313]", len(dict.Entries), calls)
+	}
+}
+
+func TestReaderFilter(t *testing.T) {
+	var seen []EntSeq
+	dict, err := NewReader(strings.NewReader(sampleText(t)), WithNormalize(), WithFilter(func(e *Entry) bool {
+		seen = append(seen, e.Id)
+		// The filter sees normalized entries.
+		return e.Kanji != nil && e.Kanji[0].Priority != nil && e.Kanji[0].Priority[0].Priority.Code != ""
+	})).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 8 {
+		t.Errorf("filter saw %v, want every entry", seen)
+	}
+	want := []EntSeq{1000220, 1358280, 1591900, 1578850, 1280640, 1512360}
+	if got := entrySeqs(dict.Entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("entries %v, want %v", got, want)
+	}
+}
+
+func TestReaderProgressReport(t *testing.T) {
+	text := sampleText(t)
+	var reports []Progress
+	rd := NewReader(strings.NewReader(text), WithProgressReport(func(p Progress) {
+		reports = append(reports, p)
+	}, 0))
+	if _, err := rd.Read(); err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 9 {
+		t.Fatalf("%d reports, want one per entry and a last one", len(reports))
+	}
+	for i, p := range reports[:8] {
+		if p.Entries != i+1 || i > 0 && p.Bytes <= reports[i-1].Bytes {
+			t.Errorf("report %d: %+v after %+v", i, p, reports[max(i-1, 0)])
+		}
+	}
+	end := int64(strings.LastIndex(text, ">") + 1)
+	if last := reports[8]; last != rd.Progress() || last.Entries != 8 || last.Bytes != end {
+		t.Errorf("last report %+v, want 8 entries and %d bytes", last, end)
+	}
+
+	// Reports are spaced by the interval.
+	reports = nil
+	if _, err := NewReader(strings.NewReader(text), WithProgressReport(func(p Progress) {
+		reports = append(reports, p)
+	}, time.Hour)).Read(); err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 || reports[0].Entries != 1 || reports[1].Entries != 8 {
+		t.Errorf("reports %+v, want the first entry and the end", reports)
+	}
+}
+
+func TestReadContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	dict, err := ReadContext(ctx, strings.NewReader(sampleText(t)))
+	if !errors.Is(err, context.Canceled) || len(dict.Entries) != 0 {
+		t.Errorf("read %d entries with error %v from a cancelled context", len(dict.Entries), err)
+	}
+
+	// Cancelled while reading.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	dict, err = ReadContext(ctx, bytes.NewReader(repeatedSample(t, 313)), WithProgress(func(n int) {
+		if n == 1000 {
+			cancel()
+		}
+	}))
+	if !errors.Is(err, context.Canceled) || len(dict.Entries) != 1000 {
+		t.Errorf("read %d entries with error %v, want 1000 and cancellation", len(dict.Entries), err)
+	}
+
+	// Entry by entry.
+	ctx, cancel = context.WithCancel(context.Background())
+	rd := NewReader(strings.NewReader(sampleText(t)), WithContext(ctx))
+	if _, err := rd.Next(); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if _, err := rd.Next(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Next after cancellation: %v", err)
+	}
+}
+
+func TestGlossFilter(t *testing.T) {
+	text := `<sense><pos>n</pos><gloss>dog</gloss><gloss xml:lang="ger">Hund</gloss>` +
+		`<gloss xml:lang="fre">chien<b>!</b></gloss><gloss xml:lang="eng">hound</gloss></sense>`
+	f := glossFilter{xml.NewDecoder(strings.NewReader(text)), map[string]bool{"eng": true, "fre": true}}
+	var got []string
+	for {
+		tok, err := f.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if data, ok := tok.(xml.CharData); ok {
+			got = append(got, string(data))
+		}
+	}
+	if want := []string{"n", "dog", "chien", "!", "hound"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("text %q, want %q", got, want)
+	}
+}
+
+func TestDropEmptySenses(t *testing.T) {
+	sense := func(pos []Position, glosses ...string) Sense {
+		return Sense{Position: pos, Gloss: glosses}
+	}
+	v1, n := []Position{Verb1}, []Position{"n"}
+	tests := []struct {
+		name  string
+		sense []Sense
+		want  []Sense
+	}{
+		{"kept", []Sense{sense(v1, "a"), sense(nil, "b")}, []Sense{sense(v1, "a"), sense(nil, "b")}},
+		{"inherited", []Sense{sense(v1), sense(nil, "b"), sense(nil, "c")},
+			[]Sense{sense(v1, "b"), sense(nil, "c")}},
+		{"own pos", []Sense{sense(v1), sense(n, "b")}, []Sense{sense(n, "b")}},
+		{"latest pos", []Sense{sense(v1), sense(n), sense(nil), sense(nil, "d")}, []Sense{sense(n, "d")}},
+		{"between", []Sense{sense(v1, "a"), sense(n), sense(v1, "c")}, []Sense{sense(v1, "a"), sense(v1, "c")}},
+		{"last", []Sense{sense(v1, "a"), sense(n)}, []Sense{sense(v1, "a")}},
+		{"all", []Sense{sense(v1), sense(nil)}, []Sense{}},
+	}
+	for _, test := range tests {
+		e := Entry{Sense: test.sense}
+		e.dropEmptySenses()
+		if !reflect.DeepEqual(e.Sense, test.want) {
+			t.Errorf("%s: senses %+v, want %+v", test.name, e.Sense, test.want)
+		}
+	}
+}
+
+func TestReaderLanguagesPositions(t *testing.T) {
+	text := `<JMdict><entry><ent_seq>1</ent_seq><r_ele><reb>いぬ</reb></r_ele>` +
+		`<sense><pos>&n;</pos><gloss xml:lang="ger">Hund</gloss></sense>` +
+		`<sense><gloss>dog</gloss></sense>` +
+		`<sense><pos>&v1;</pos><gloss xml:lang="ger">hetzen</gloss></sense></entry>` +
+		`<entry><ent_seq>2</ent_seq><r_ele><reb>ねこ</reb></r_ele>` +
+		`<sense><pos>&n;</pos><gloss xml:lang="ger">Katze</gloss></sense></entry></JMdict>`
+	dict, err := NewReader(strings.NewReader(text), WithLanguages("eng")).Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dict.Entries) != 1 {
+		t.Fatalf("read %d entries, want 1", len(dict.Entries))
+	}
+	e := &dict.Entries[0]
+	if len(e.Sense) != 1 || !reflect.DeepEqual(e.Positions(0), []Position{"n"}) ||
+		!reflect.DeepEqual(e.Sense[0].Gloss, []string{"dog"}) {
+		t.Errorf("senses %+v, want dog as a noun", e.Sense)
+	}
+}
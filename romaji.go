@@ -0,0 +1,136 @@
+package jmdict
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Romaji syllables and their hiragana, covering Hepburn, Kunrei-shiki
+// and the common IME spellings.
+var romajiKana = map[string]string{
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"sa": "さ", "si": "し", "shi": "し", "su": "す", "se": "せ", "so": "そ",
+	"za": "ざ", "zi": "じ", "ji": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"ta": "た", "ti": "ち", "chi": "ち", "tu": "つ", "tsu": "つ", "te": "て", "to": "と",
+	"da": "だ", "di": "ぢ", "du": "づ", "dzu": "づ", "de": "で", "do": "ど",
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"ha": "は", "hi": "ひ", "hu": "ふ", "fu": "ふ", "he": "へ", "ho": "ほ",
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"ya": "や", "yu": "ゆ", "yo": "よ",
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"la": "ら", "li": "り", "lu": "る", "le": "れ", "lo": "ろ",
+	"wa": "わ", "wi": "ゐ", "we": "ゑ", "wo": "を",
+	"n'": "ん", "xn": "ん",
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+	"sha": "しゃ", "shu": "しゅ", "sho": "しょ", "she": "しぇ",
+	"sya": "しゃ", "syu": "しゅ", "syo": "しょ",
+	"ja": "じゃ", "ju": "じゅ", "jo": "じょ", "je": "じぇ",
+	"jya": "じゃ", "jyu": "じゅ", "jyo": "じょ",
+	"zya": "じゃ", "zyu": "じゅ", "zyo": "じょ",
+	"cha": "ちゃ", "chu": "ちゅ", "cho": "ちょ", "che": "ちぇ",
+	"tya": "ちゃ", "tyu": "ちゅ", "tyo": "ちょ",
+	"cya": "ちゃ", "cyu": "ちゅ", "cyo": "ちょ",
+	"dya": "ぢゃ", "dyu": "ぢゅ", "dyo": "ぢょ",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+	"fa": "ふぁ", "fi": "ふぃ", "fe": "ふぇ", "fo": "ふぉ",
+	"thi": "てぃ", "dhi": "でぃ",
+	"tsa": "つぁ", "tse": "つぇ", "tso": "つぉ",
+	"va": "ゔぁ", "vi": "ゔぃ", "vu": "ゔ", "ve": "ゔぇ", "vo": "ゔぉ",
+	"xa": "ぁ", "xi": "ぃ", "xu": "ぅ", "xe": "ぇ", "xo": "ぉ",
+	"xya": "ゃ", "xyu": "ゅ", "xyo": "ょ", "xtu": "っ", "xtsu": "っ",
+	"-": "ー",
+}
+
+// Vowels written with a macron or circumflex, which lengthen the vowel.
+var romajiLong = map[rune]string{
+	'ā': "aa", 'ī': "ii", 'ū': "uu", 'ē': "ee", 'ō': "ou",
+	'â': "aa", 'î': "ii", 'û': "uu", 'ê': "ee", 'ô': "ou",
+}
+
+// RomajiToKana converts romanized Japanese to hiragana. Hepburn, Kunrei
+// and the spellings accepted by Japanese input methods are recognized,
+// including doubled consonants for the sokuon, "n", "nn" and "n'" for
+// the syllabic n, "m" before b, m and p, and macrons for long vowels.
+// Case is ignored. Text which cannot be converted, including kana, is
+// copied unchanged.
+func RomajiToKana(romaji string) string {
+	var src strings.Builder
+	for _, c := range strings.ToLower(romaji) {
+		if long, ok := romajiLong[c]; ok {
+			src.WriteString(long)
+		} else {
+			src.WriteRune(c)
+		}
+	}
+	s := src.String()
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		// Traditional Hepburn writes the syllabic n as m before b, m
+		// and p, as in "sempai".
+		if s[i] == 'm' && i+1 < len(s) && strings.IndexByte("bmp", s[i+1]) >= 0 {
+			b.WriteString("ん")
+			i++
+			continue
+		}
+		// A doubled consonant other than n is the sokuon, as is the
+		// "t" of "tch".
+		if i+1 < len(s) && isRomajiConsonant(s[i]) && s[i] != 'n' &&
+			(s[i+1] == s[i] || s[i] == 't' && s[i+1] == 'c') {
+			b.WriteString("っ")
+			i++
+			continue
+		}
+		// "nn" is the syllabic n, unless the second n begins a syllable
+		// as in "konnichiwa".
+		if strings.HasPrefix(s[i:], "nn") {
+			b.WriteString("ん")
+			if i+2 < len(s) && strings.IndexByte("aeiouy", s[i+2]) >= 0 {
+				i++
+			} else {
+				i += 2
+			}
+			continue
+		}
+		matched := false
+		for n := 4; n > 0; n-- {
+			if i+n > len(s) {
+				continue
+			}
+			if kana, ok := romajiKana[s[i:i+n]]; ok {
+				b.WriteString(kana)
+				i += n
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		// A lone n, before a consonant or at the end, is the syllabic n.
+		if s[i] == 'n' {
+			b.WriteString("ん")
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+size])
+		i += size
+	}
+	return b.String()
+}
+
+func isRomajiConsonant(c byte) bool {
+	return c < 0x80 && unicode.IsLetter(rune(c)) && !strings.ContainsRune("aeiou", rune(c))
+}
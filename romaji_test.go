@@ -0,0 +1,32 @@
+package jmdict
+
+import "testing"
+
+func TestRomajiToKana(t *testing.T) {
+	tests := []struct {
+		romaji, kana string
+	}{
+		{"kodomo", "こども"},
+		{"KoDoMo", "こども"},
+		{"tabemasu", "たべます"},
+		{"Tōkyō", "とうきょう"},
+		{"shinbun", "しんぶん"},
+		{"shimbun", "しんぶん"},
+		{"kon'ya", "こんや"},
+		{"n", "ん"},
+		{"gakkou", "がっこう"},
+		{"chotto", "ちょっと"},
+		{"tyotto", "ちょっと"},
+		{"fu", "ふ"},
+		{"hu", "ふ"},
+		{"jaa", "じゃあ"},
+		{"かな", "かな"},
+		{"xyz", "xyz"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		if got := RomajiToKana(test.romaji); got != test.kana {
+			t.Errorf("RomajiToKana(%q) = %q, want %q", test.romaji, got, test.kana)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package jmdict
+
+import (
+	"bytes"
+	"io"
+)
+
+var (
+	entryOpen  = []byte("<entry>")
+	entryClose = []byte("</entry>")
+)
+
+// An entryChunk is the text of a single entry element, from <entry> to
+// </entry> inclusive, with its position in the input.
+type entryChunk struct {
+	data   []byte
+	offset int64 // byte offset of <entry>
+	line   int   // 1-based line of <entry>
+}
+
+// entrySplitter cuts a JMdict document into entry elements without
+// parsing it, so that entries can be decoded independently. Everything
+// outside entry elements, such as the DTD and the root element, is
+// skipped.
+type entrySplitter struct {
+	r      io.Reader
+	buf    []byte
+	start  int   // start of unconsumed data in buf
+	offset int64 // input offset of buf[0]
+	line   int   // line of buf[start]
+	eof    bool
+}
+
+func newEntrySplitter(r io.Reader) *entrySplitter {
+	return &entrySplitter{r: r, line: 1}
+}
+
+// Returns the next entry, or io.EOF once the input holds no more. An
+// entry left unterminated by the end of the input is returned with
+// io.ErrUnexpectedEOF. Returned data is not shared with the splitter.
+func (s *entrySplitter) next() (entryChunk, error) {
+	for {
+		pending := s.buf[s.start:]
+		if i := bytes.Index(pending, entryOpen); i >= 0 {
+			if j := bytes.Index(pending[i:], entryClose); j >= 0 {
+				end := i + j + len(entryClose)
+				// An unterminated entry ends where the next one begins.
+				body := pending[i+len(entryOpen) : i+j]
+				if k := bytes.Index(body, entryOpen); k >= 0 {
+					end = i + len(entryOpen) + k
+				}
+				s.line += bytes.Count(pending[:i], []byte{'\n'})
+				chunk := entryChunk{
+					data:   append([]byte(nil), pending[i:end]...),
+					offset: s.offset + int64(s.start+i),
+					line:   s.line,
+				}
+				s.line += bytes.Count(chunk.data, []byte{'\n'})
+				s.start += end
+				return chunk, nil
+			}
+		}
+		if s.eof {
+			i := bytes.Index(pending, entryOpen)
+			if i < 0 {
+				return entryChunk{}, io.EOF
+			}
+			s.line += bytes.Count(pending[:i], []byte{'\n'})
+			chunk := entryChunk{
+				data:   append([]byte(nil), pending[i:]...),
+				offset: s.offset + int64(s.start+i),
+				line:   s.line,
+			}
+			s.start = len(s.buf)
+			return chunk, io.ErrUnexpectedEOF
+		}
+		if err := s.fill(); err != nil {
+			return entryChunk{}, err
+		}
+	}
+}
+
+// Reads more input, discarding consumed data. Data before a partial
+// "<entry>" at the end of the buffer is kept until the tag is complete.
+func (s *entrySplitter) fill() error {
+	if s.start > 0 {
+		pending := s.buf[s.start:]
+		if bytes.Index(pending, entryOpen) < 0 {
+			// Only a possible prefix of <entry> needs to be kept.
+			keep := len(entryOpen) - 1
+			if keep > len(pending) {
+				keep = len(pending)
+			}
+			s.line += bytes.Count(pending[:len(pending)-keep], []byte{'\n'})
+			s.start += len(pending) - keep
+			pending = s.buf[s.start:]
+		}
+		n := copy(s.buf, pending)
+		s.offset += int64(s.start)
+		s.buf = s.buf[:n]
+		s.start = 0
+	}
+
+	if cap(s.buf)-len(s.buf) < 64*1024 {
+		grown := make([]byte, len(s.buf), 2*cap(s.buf)+64*1024)
+		copy(grown, s.buf)
+		s.buf = grown
+	}
+	n, err := s.r.Read(s.buf[len(s.buf):cap(s.buf)])
+	s.buf = s.buf[:len(s.buf)+n]
+	if err == io.EOF {
+		s.eof = true
+		return nil
+	}
+	return err
+}
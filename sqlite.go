@@ -0,0 +1,275 @@
+package jmdict
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// Schema created by WriteSQLite. Tags of every kind share one table and
+// are referenced by (kind, name), where kind is one of pos, field, misc,
+// dial or orth.
+var sqliteSchema = []string{
+	`CREATE TABLE tag (
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL,
+		PRIMARY KEY (kind, name))`,
+	`CREATE TABLE entry (
+		id INTEGER PRIMARY KEY)`,
+	`CREATE TABLE kanji (
+		id INTEGER PRIMARY KEY,
+		entry_id INTEGER NOT NULL REFERENCES entry(id),
+		position INTEGER NOT NULL,
+		keb TEXT NOT NULL)`,
+	`CREATE TABLE kanji_tag (
+		kanji_id INTEGER NOT NULL REFERENCES kanji(id),
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		FOREIGN KEY (kind, name) REFERENCES tag(kind, name))`,
+	`CREATE TABLE kanji_priority (
+		kanji_id INTEGER NOT NULL REFERENCES kanji(id),
+		raw TEXT NOT NULL,
+		code TEXT NOT NULL,
+		rank INTEGER NOT NULL)`,
+	`CREATE TABLE reading (
+		id INTEGER PRIMARY KEY,
+		entry_id INTEGER NOT NULL REFERENCES entry(id),
+		position INTEGER NOT NULL,
+		reb TEXT NOT NULL,
+		nokanji INTEGER NOT NULL)`,
+	`CREATE TABLE reading_restriction (
+		reading_id INTEGER NOT NULL REFERENCES reading(id),
+		keb TEXT NOT NULL)`,
+	`CREATE TABLE reading_tag (
+		reading_id INTEGER NOT NULL REFERENCES reading(id),
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		FOREIGN KEY (kind, name) REFERENCES tag(kind, name))`,
+	`CREATE TABLE reading_priority (
+		reading_id INTEGER NOT NULL REFERENCES reading(id),
+		raw TEXT NOT NULL,
+		code TEXT NOT NULL,
+		rank INTEGER NOT NULL)`,
+	`CREATE TABLE sense (
+		id INTEGER PRIMARY KEY,
+		entry_id INTEGER NOT NULL REFERENCES entry(id),
+		position INTEGER NOT NULL)`,
+	`CREATE TABLE sense_restriction (
+		sense_id INTEGER NOT NULL REFERENCES sense(id),
+		kanji TEXT,
+		reading TEXT)`,
+	`CREATE TABLE sense_tag (
+		sense_id INTEGER NOT NULL REFERENCES sense(id),
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		FOREIGN KEY (kind, name) REFERENCES tag(kind, name))`,
+	`CREATE TABLE sense_info (
+		sense_id INTEGER NOT NULL REFERENCES sense(id),
+		text TEXT NOT NULL)`,
+	`CREATE TABLE sense_xref (
+		sense_id INTEGER NOT NULL REFERENCES sense(id),
+		type TEXT NOT NULL,
+		raw TEXT NOT NULL,
+		headword TEXT NOT NULL,
+		reading TEXT,
+		sense_number INTEGER)`,
+	`CREATE TABLE gloss (
+		id INTEGER PRIMARY KEY,
+		sense_id INTEGER NOT NULL REFERENCES sense(id),
+		position INTEGER NOT NULL,
+		text TEXT NOT NULL)`,
+	`CREATE INDEX kanji_keb ON kanji(keb)`,
+	`CREATE INDEX kanji_entry ON kanji(entry_id)`,
+	`CREATE INDEX reading_reb ON reading(reb)`,
+	`CREATE INDEX reading_entry ON reading(entry_id)`,
+	`CREATE INDEX sense_entry ON sense(entry_id)`,
+	`CREATE INDEX gloss_sense ON gloss(sense_id)`,
+	`CREATE VIRTUAL TABLE gloss_fts USING fts5(
+		text, content='gloss', content_rowid='id')`,
+	`CREATE VIRTUAL TABLE headword_fts USING fts5(
+		headword, entry_id UNINDEXED, tokenize='trigram')`,
+}
+
+// WriteSQLite creates a normalized schema in db and writes dict to it
+// in a single transaction. Glosses and headwords are indexed with FTS5.
+//
+// The package does not depend on a SQLite driver. Open db with any
+// driver which supports FTS5, for instance the pure Go
+// modernc.org/sqlite, which does not require cgo.
+func WriteSQLite(db *sql.DB, dict JMDict) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := writeSQLite(tx, dict); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+type sqliteWriter struct {
+	tx    *sql.Tx
+	stmts map[string]*sql.Stmt
+	err   error
+}
+
+func writeSQLite(tx *sql.Tx, dict JMDict) error {
+	for _, stmt := range sqliteSchema {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	w := &sqliteWriter{tx: tx, stmts: make(map[string]*sql.Stmt)}
+	defer func() {
+		for _, stmt := range w.stmts {
+			stmt.Close()
+		}
+	}()
+
+	for k, v := range positionDescriptions {
+		w.exec(`INSERT INTO tag VALUES (?, ?, ?)`, "pos", string(k), v)
+	}
+	for k, v := range fieldDescriptions {
+		w.exec(`INSERT INTO tag VALUES (?, ?, ?)`, "field", string(k), v)
+	}
+	for k, v := range miscDescriptions {
+		w.exec(`INSERT INTO tag VALUES (?, ?, ?)`, "misc", string(k), v)
+	}
+	for k, v := range dialectDescriptions {
+		w.exec(`INSERT INTO tag VALUES (?, ?, ?)`, "dial", string(k), v)
+	}
+	for k, v := range orthographyDescriptions {
+		w.exec(`INSERT INTO tag VALUES (?, ?, ?)`, "orth", string(k), v)
+	}
+
+	for i := range dict.Entries {
+		w.entry(&dict.Entries[i])
+		if w.err != nil {
+			return w.err
+		}
+	}
+	w.exec(`INSERT INTO gloss_fts(gloss_fts) VALUES ('rebuild')`)
+	return w.err
+}
+
+// Executes query with a cached prepared statement, returning the id of
+// the inserted row. Once an error occurs further calls do nothing.
+func (w *sqliteWriter) exec(query string, args ...interface{}) int64 {
+	if w.err != nil {
+		return 0
+	}
+	stmt, ok := w.stmts[query]
+	if !ok {
+		stmt, w.err = w.tx.Prepare(query)
+		if w.err != nil {
+			return 0
+		}
+		w.stmts[query] = stmt
+	}
+	res, err := stmt.Exec(args...)
+	if err != nil {
+		w.err = err
+		return 0
+	}
+	id, _ := res.LastInsertId()
+	return id
+}
+
+// Records a tag reference, adding tags which are not in the description
+// maps with an empty description so that foreign keys hold.
+func (w *sqliteWriter) tag(table, column string, id int64, kind, name string) {
+	w.exec(`INSERT OR IGNORE INTO tag VALUES (?, ?, '')`, kind, name)
+	w.exec(`INSERT INTO `+table+` (`+column+`, kind, name) VALUES (?, ?, ?)`, id, kind, name)
+}
+
+func (w *sqliteWriter) entry(e *Entry) {
+	w.exec(`INSERT INTO entry (id) VALUES (?)`, uint64(e.Id))
+
+	for i, k := range e.Kanji {
+		id := w.exec(`INSERT INTO kanji (entry_id, position, keb) VALUES (?, ?, ?)`,
+			uint64(e.Id), i, string(k.Phrase))
+		w.exec(`INSERT INTO headword_fts (headword, entry_id) VALUES (?, ?)`,
+			string(k.Phrase), uint64(e.Id))
+		for _, o := range k.Info {
+			w.tag("kanji_tag", "kanji_id", id, "orth", string(o))
+		}
+		for _, p := range k.Priority {
+			p := p.Priority.Parse()
+			w.exec(`INSERT INTO kanji_priority VALUES (?, ?, ?, ?)`,
+				id, p.Raw, string(p.Code), p.Rank)
+		}
+	}
+
+	for i, r := range e.Reading {
+		id := w.exec(`INSERT INTO reading (entry_id, position, reb, nokanji) VALUES (?, ?, ?, ?)`,
+			uint64(e.Id), i, string(r.Phrase), r.ImproperReading != nil)
+		w.exec(`INSERT INTO headword_fts (headword, entry_id) VALUES (?, ?)`,
+			string(r.Phrase), uint64(e.Id))
+		for _, restr := range r.Restrict {
+			w.exec(`INSERT INTO reading_restriction VALUES (?, ?)`, id, string(restr))
+		}
+		for _, o := range r.Orthography {
+			w.tag("reading_tag", "reading_id", id, "orth", string(o))
+		}
+		for _, p := range r.Priority {
+			p := p.Priority.Parse()
+			w.exec(`INSERT INTO reading_priority VALUES (?, ?, ?, ?)`,
+				id, p.Raw, string(p.Code), p.Rank)
+		}
+	}
+
+	for i := range e.Sense {
+		s := &e.Sense[i]
+		id := w.exec(`INSERT INTO sense (entry_id, position) VALUES (?, ?)`,
+			uint64(e.Id), i)
+		for _, k := range s.KanjiRestrict {
+			w.exec(`INSERT INTO sense_restriction (sense_id, kanji) VALUES (?, ?)`, id, k)
+		}
+		for _, r := range s.ReadingRestrict {
+			w.exec(`INSERT INTO sense_restriction (sense_id, reading) VALUES (?, ?)`, id, r)
+		}
+		for _, p := range s.Position {
+			w.tag("sense_tag", "sense_id", id, "pos", string(p))
+		}
+		for _, f := range s.Field {
+			w.tag("sense_tag", "sense_id", id, "field", string(f))
+		}
+		for _, m := range s.Misc {
+			w.tag("sense_tag", "sense_id", id, "misc", string(m))
+		}
+		for _, d := range s.Dialect {
+			w.tag("sense_tag", "sense_id", id, "dial", string(d))
+		}
+		for _, info := range s.Info {
+			w.exec(`INSERT INTO sense_info VALUES (?, ?)`, id, info)
+		}
+		for _, x := range s.Xref {
+			w.xref(id, "xref", x)
+		}
+		for _, a := range s.Antonym {
+			w.xref(id, "ant", a)
+		}
+		for j, g := range s.Gloss {
+			w.exec(`INSERT INTO gloss (sense_id, position, text) VALUES (?, ?, ?)`, id, j, g)
+		}
+	}
+}
+
+// Splits an xref or ant element into its headword, optional reading and
+// optional sense number.
+func (w *sqliteWriter) xref(senseId int64, typ, raw string) {
+	parts := strings.Split(raw, xrefSeparator)
+	var reading, number interface{}
+	for _, p := range parts[1:] {
+		if n, err := strconv.Atoi(p); err == nil {
+			number = n
+		} else {
+			reading = p
+		}
+	}
+	w.exec(`INSERT INTO sense_xref VALUES (?, ?, ?, ?, ?, ?)`,
+		senseId, typ, raw, parts[0], reading, number)
+}
@@ -0,0 +1,79 @@
+package jmdict
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestWriteSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "jmdict.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := WriteSQLite(db, readSample(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each query gives one column, compared as text.
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`SELECT count(*) FROM entry`, []string{"8"}},
+		{`SELECT k.entry_id || ' ' || t.name || ' ' || tag.description
+			FROM kanji k JOIN kanji_tag t ON t.kanji_id = k.id
+			JOIN tag ON tag.kind = t.kind AND tag.name = t.name
+			WHERE k.keb = '喰べる'`,
+			[]string{"1358280 iK " + orthographyDescriptions[IrregularKanji]}},
+		{`SELECT p.raw || ' ' || p.code || ' ' || p.rank
+			FROM reading r JOIN reading_priority p ON p.reading_id = r.id
+			WHERE r.reb = 'これ' ORDER BY p.raw`,
+			[]string{"ichi1 ichi 1", "news1 news 1", "nf01 nf 1"}},
+		{`SELECT r.reb || ' ' || r.nokanji FROM reading r WHERE r.entry_id = 1080180 ORDER BY r.position`,
+			[]string{"ロンドン 0", "ロンドン 1"}},
+		{`SELECT x.keb FROM reading r JOIN reading_restriction x ON x.reading_id = r.id WHERE r.reb = 'こ'`,
+			[]string{"子供"}},
+		{`SELECT s.position || ' ' || t.kind || ' ' || t.name
+			FROM sense s JOIN sense_tag t ON t.sense_id = s.id
+			WHERE s.entry_id = 1591900 ORDER BY s.position, t.kind, t.name`,
+			[]string{"0 misc uk", "0 pos n", "1 dial ksb", "1 field Buddh", "1 misc arch"}},
+		{`SELECT x.reading FROM sense s JOIN sense_restriction x ON x.sense_id = s.id
+			WHERE s.entry_id = 1591900`,
+			[]string{"こども"}},
+		{`SELECT x.type || ' ' || x.headword || ' ' || x.sense_number || ' ' || (x.reading IS NULL)
+			FROM sense s JOIN sense_xref x ON x.sense_id = s.id WHERE s.entry_id = 1358280`,
+			[]string{"xref 食う 1 1"}},
+		{`SELECT s.entry_id FROM gloss_fts JOIN gloss g ON g.id = gloss_fts.rowid
+			JOIN sense s ON s.id = g.sense_id WHERE gloss_fts MATCH 'offspring'`,
+			[]string{"1591900"}},
+		{`SELECT DISTINCT entry_id FROM headword_fts WHERE headword_fts MATCH 'べんき'`,
+			[]string{"1512360"}},
+	}
+	for _, test := range tests {
+		rows, err := db.Query(test.query)
+		if err != nil {
+			t.Errorf("%s: %v", test.query, err)
+			continue
+		}
+		var got []string
+		for rows.Next() {
+			var s string
+			if err := rows.Scan(&s); err != nil {
+				t.Error(err)
+			}
+			got = append(got, s)
+		}
+		if err := rows.Err(); err != nil {
+			t.Error(err)
+		}
+		rows.Close()
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s\n= %q, want %q", test.query, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package jmdict
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// StarDictInfo holds the metadata written to the .ifo file.
+type StarDictInfo struct {
+	BookName    string
+	Author      string
+	Email       string
+	Website     string
+	Description string
+	Date        string
+
+	// Write the definitions as a dictzip compressed .dict.dz rather
+	// than a plain .dict file.
+	Compress bool
+}
+
+// Uncompressed size of each independently seekable dictzip chunk
+const dictzipChunkSize = 58315
+
+type stardictWord struct {
+	word  string
+	index uint32 // offset into .dict for idx words, idx position for syn words
+	size  uint32
+}
+
+// WriteStarDict writes dict as a StarDict 3.0.0 dictionary to the files
+// base.ifo, base.idx, base.syn and base.dict (or base.dict.dz). Each
+// entry is indexed under its first kanji/reading pair and the remaining
+// kanji and reading elements are written as synonyms, so that every
+// variant resolves to the entry. Definitions are HTML.
+func WriteStarDict(base string, dict JMDict, info StarDictInfo) error {
+	var data bytes.Buffer
+	var words, syns []stardictWord
+	var b strings.Builder
+
+	for i := range dict.Entries {
+		e := &dict.Entries[i]
+		pairs := e.Pairs()
+		if len(pairs) == 0 {
+			continue
+		}
+		b.Reset()
+		writeEntryHTML(&b, e, "bword://")
+		headword := pairs[0].Headword()
+		words = append(words, stardictWord{headword,
+			uint32(data.Len()), uint32(b.Len())})
+		data.WriteString(b.String())
+
+		// Synonyms temporarily record the entry number and are
+		// rewritten to idx positions once the idx is sorted.
+		seen := map[string]bool{headword: true}
+		addSyn := func(s string) {
+			if !seen[s] {
+				seen[s] = true
+				syns = append(syns, stardictWord{s, uint32(len(words) - 1), 0})
+			}
+		}
+		for _, k := range e.Kanji {
+			addSyn(string(k.Phrase))
+		}
+		for _, r := range e.Reading {
+			addSyn(string(r.Phrase))
+		}
+	}
+
+	order := make([]int, len(words))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return stardictLess(words[order[i]].word, words[order[j]].word)
+	})
+	position := make([]uint32, len(words))
+	for pos, i := range order {
+		position[i] = uint32(pos)
+	}
+	for i := range syns {
+		syns[i].index = position[syns[i].index]
+	}
+	sort.SliceStable(syns, func(i, j int) bool {
+		return stardictLess(syns[i].word, syns[j].word)
+	})
+
+	var idx bytes.Buffer
+	for _, i := range order {
+		idx.WriteString(words[i].word)
+		idx.WriteByte(0)
+		binary.Write(&idx, binary.BigEndian, words[i].index)
+		binary.Write(&idx, binary.BigEndian, words[i].size)
+	}
+	var syn bytes.Buffer
+	for _, s := range syns {
+		syn.WriteString(s.word)
+		syn.WriteByte(0)
+		binary.Write(&syn, binary.BigEndian, s.index)
+	}
+
+	if err := os.WriteFile(base+".idx", idx.Bytes(), 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(base+".syn", syn.Bytes(), 0644); err != nil {
+		return err
+	}
+	if info.Compress {
+		f, err := os.Create(base + ".dict.dz")
+		if err != nil {
+			return err
+		}
+		if err := writeDictzip(f, data.Bytes()); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	} else if err := os.WriteFile(base+".dict", data.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	var ifo strings.Builder
+	ifo.WriteString("StarDict's dict ifo file\nversion=3.0.0\n")
+	fmt.Fprintf(&ifo, "bookname=%s\n", stardictInfoValue(info.BookName))
+	fmt.Fprintf(&ifo, "wordcount=%d\n", len(words))
+	fmt.Fprintf(&ifo, "synwordcount=%d\n", len(syns))
+	fmt.Fprintf(&ifo, "idxfilesize=%d\n", idx.Len())
+	for _, kv := range [][2]string{
+		{"author", info.Author},
+		{"email", info.Email},
+		{"website", info.Website},
+		{"description", info.Description},
+		{"date", info.Date},
+	} {
+		if kv[1] != "" {
+			fmt.Fprintf(&ifo, "%s=%s\n", kv[0], stardictInfoValue(kv[1]))
+		}
+	}
+	ifo.WriteString("sametypesequence=h\n")
+	return os.WriteFile(base+".ifo", []byte(ifo.String()), 0644)
+}
+
+// Values in the .ifo file may not span lines
+func stardictInfoValue(s string) string {
+	return strings.NewReplacer("\r\n", "<br>", "\n", "<br>").Replace(s)
+}
+
+// The ordering StarDict expects of .idx and .syn files: an ASCII case
+// insensitive comparison, falling back to a byte comparison.
+func stardictLess(a, b string) bool {
+	if c := asciiCaseCompare(a, b); c != 0 {
+		return c < 0
+	}
+	return a < b
+}
+
+func asciiCaseCompare(a, b string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		ca, cb := asciiLower(a[i]), asciiLower(b[i])
+		if ca != cb {
+			return int(ca) - int(cb)
+		}
+	}
+	return len(a) - len(b)
+}
+
+func asciiLower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// writeDictzip writes data to w in the dictzip format: a gzip stream
+// whose RA extra field records the compressed size of each chunk, so
+// that readers can seek without decompressing the whole file. Chunks
+// are compressed independently.
+func writeDictzip(w io.Writer, data []byte) error {
+	var body bytes.Buffer
+	fw, err := flate.NewWriter(&body, flate.BestCompression)
+	if err != nil {
+		return err
+	}
+	var sizes []uint16
+	for off := 0; ; off += dictzipChunkSize {
+		end := off + dictzipChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		before := body.Len()
+		fw.Write(data[off:end])
+		if end == len(data) {
+			err = fw.Close()
+		} else {
+			// A sync flush ends the chunk on a byte boundary, and a
+			// fresh compressor keeps the next chunk from referring
+			// back into this one, so each chunk inflates on its own.
+			err = fw.Flush()
+			fw.Reset(&body)
+		}
+		if err != nil {
+			return err
+		}
+		if body.Len()-before > 0xffff {
+			return fmt.Errorf("jmdict: dictzip chunk exceeds 64KiB")
+		}
+		sizes = append(sizes, uint16(body.Len()-before))
+		if end == len(data) {
+			break
+		}
+	}
+
+	var extra bytes.Buffer
+	extra.WriteString("RA")
+	binary.Write(&extra, binary.LittleEndian, uint16(6+2*len(sizes)))
+	binary.Write(&extra, binary.LittleEndian, uint16(1))
+	binary.Write(&extra, binary.LittleEndian, uint16(dictzipChunkSize))
+	binary.Write(&extra, binary.LittleEndian, uint16(len(sizes)))
+	binary.Write(&extra, binary.LittleEndian, sizes)
+
+	bw := bufio.NewWriter(w)
+	// ID1 ID2 CM FLG(FEXTRA) MTIME(4) XFL(best) OS(unix)
+	bw.Write([]byte{0x1f, 0x8b, 8, 4, 0, 0, 0, 0, 2, 3})
+	binary.Write(bw, binary.LittleEndian, uint16(extra.Len()))
+	bw.Write(extra.Bytes())
+	bw.Write(body.Bytes())
+	binary.Write(bw, binary.LittleEndian, crc32.ChecksumIEEE(data))
+	binary.Write(bw, binary.LittleEndian, uint32(len(data)))
+	return bw.Flush()
+}
@@ -0,0 +1,150 @@
+package jmdict
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteDictzip(t *testing.T) {
+	var data bytes.Buffer
+	for i := 0; data.Len() < 3*dictzipChunkSize+1000; i++ {
+		fmt.Fprintf(&data, "<p>definition %d of %d</p>\n", i, i*i%977)
+	}
+	var dz bytes.Buffer
+	if err := writeDictzip(&dz, data.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The whole file is an ordinary gzip stream.
+	zr, err := gzip.NewReader(bytes.NewReader(dz.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	whole, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(whole, data.Bytes()) {
+		t.Fatal("gzip stream does not decompress to the data")
+	}
+
+	// Each chunk inflates on its own from the offset given by the RA
+	// field.
+	raw := dz.Bytes()
+	xlen := int(binary.LittleEndian.Uint16(raw[10:]))
+	extra := raw[12 : 12+xlen]
+	if string(extra[:2]) != "RA" {
+		t.Fatalf("extra field %q, want RA", extra[:2])
+	}
+	chunkLen := int(binary.LittleEndian.Uint16(extra[6:]))
+	count := int(binary.LittleEndian.Uint16(extra[8:]))
+	if want := (data.Len() + chunkLen - 1) / chunkLen; count != want {
+		t.Fatalf("%d chunks, want %d", count, want)
+	}
+	offset := 12 + xlen
+	for i := 0; i < count; i++ {
+		size := int(binary.LittleEndian.Uint16(extra[10+2*i:]))
+		start := i * chunkLen
+		end := start + chunkLen
+		if end > data.Len() {
+			end = data.Len()
+		}
+		chunk := make([]byte, end-start)
+		fr := flate.NewReader(bytes.NewReader(raw[offset : offset+size]))
+		if _, err := io.ReadFull(fr, chunk); err != nil {
+			t.Fatalf("chunk %d: %v", i, err)
+		}
+		if !bytes.Equal(chunk, data.Bytes()[start:end]) {
+			t.Fatalf("chunk %d does not match the data", i)
+		}
+		offset += size
+	}
+}
+
+func TestWriteStarDict(t *testing.T) {
+	dict := readSample(t)
+	base := filepath.Join(t.TempDir(), "jmdict")
+	if err := WriteStarDict(base, dict, StarDictInfo{BookName: "JMdict\nsample"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ifo, err := os.ReadFile(base + ".ifo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"version=3.0.0", "bookname=JMdict<br>sample", "wordcount=8"} {
+		if !strings.Contains(string(ifo), "\n"+line+"\n") {
+			t.Errorf(".ifo lacks %q:\n%s", line, ifo)
+		}
+	}
+
+	idx, err := os.ReadFile(base + ".idx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	definitions, err := os.ReadFile(base + ".dict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var words []string
+	for len(idx) > 0 {
+		n := bytes.IndexByte(idx, 0)
+		word := string(idx[:n])
+		off := binary.BigEndian.Uint32(idx[n+1:])
+		size := binary.BigEndian.Uint32(idx[n+5:])
+		idx = idx[n+9:]
+		if len(words) > 0 && stardictLess(word, words[len(words)-1]) {
+			t.Errorf("%q is indexed after %q", word, words[len(words)-1])
+		}
+		words = append(words, word)
+		if word == "食べる" && !strings.Contains(string(definitions[off:off+size]), "to eat") {
+			t.Errorf("definition of 食べる is %q", definitions[off:off+size])
+		}
+	}
+	if len(words) != 8 {
+		t.Errorf("%d words indexed, want 8", len(words))
+	}
+
+	// Every other form is a synonym of its entry's word.
+	syn, err := os.ReadFile(base + ".syn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	synonyms := make(map[string]string)
+	var prev string
+	for len(syn) > 0 {
+		n := bytes.IndexByte(syn, 0)
+		word := string(syn[:n])
+		i := binary.BigEndian.Uint32(syn[n+1:])
+		syn = syn[n+5:]
+		if prev != "" && stardictLess(word, prev) {
+			t.Errorf("synonym %q is written after %q", word, prev)
+		}
+		prev = word
+		if int(i) >= len(words) {
+			t.Fatalf("synonym %q has index %d of %d words", word, i, len(words))
+		}
+		synonyms[word] = words[i]
+	}
+	want := map[string]string{
+		"喰べる": "食べる", "たべる": "食べる",
+		"子ども": "子供", "小供": "子供", "こども": "子供", "こ": "子供",
+		"是": "此れ", "これ": "此れ", "ゆく": "行く", "ロンドン": "倫敦",
+	}
+	for word, target := range want {
+		if synonyms[word] != target {
+			t.Errorf("synonym %q names %q, want %q", word, synonyms[word], target)
+		}
+	}
+	if !strings.Contains(string(ifo), fmt.Sprintf("\nsynwordcount=%d\n", len(synonyms))) {
+		t.Errorf(".ifo synwordcount does not count %d synonyms:\n%s", len(synonyms), ifo)
+	}
+}
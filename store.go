@@ -0,0 +1,315 @@
+package jmdict
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+)
+
+// The store format is designed to be memory mapped and queried without
+// decoding more than the entries asked for. All fixed width integers are
+// little endian.
+//
+//	header   magic "JMDS" | version uint32 | entries uint32 | keys uint32
+//	seqs     entries * (ent_seq uint64 | record offset uint64), by ent_seq
+//	keys     keys * (key offset uint32 | key length uint32 | slot uint32),
+//	         sorted by key and then slot
+//	blob     key bytes
+//	records  one binary snapshot encoded entry per slot
+//	crc32    covering everything before it
+//
+// A slot is the position of an entry in the seqs table. Each record holds
+// its own string table, so an entry can be decoded on its own, and
+// extends to the start of the next record.
+//
+// Version 2 added the languages of glosses to the records. Stores of
+// other versions are rejected with ErrStoreVersion and must be written
+// again.
+const (
+	storeMagic      = "JMDS"
+	storeVersion    = 2
+	storeHeaderSize = 16
+	storeSeqSize    = 16
+	storeKeySize    = 12
+)
+
+var (
+	ErrStoreFormat   = errors.New("jmdict: not a dictionary store")
+	ErrStoreChecksum = errors.New("jmdict: dictionary store checksum mismatch")
+	ErrStoreVersion  = errors.New("jmdict: unsupported dictionary store version")
+	ErrNotFound      = errors.New("jmdict: entry not found")
+	ErrStoreClosed   = errors.New("jmdict: dictionary store is closed")
+)
+
+// WriteStore writes dict to w in the store format read by OpenStore and
+// NewStore. Entries are indexed by EntSeq and by every kanji and reading
+// phrase.
+func WriteStore(w io.Writer, dict JMDict) error {
+	slots := make([]int, len(dict.Entries))
+	for i := range slots {
+		slots[i] = i
+	}
+	sort.SliceStable(slots, func(i, j int) bool {
+		return dict.Entries[slots[i]].Id < dict.Entries[slots[j]].Id
+	})
+
+	type storeKey struct {
+		key  string
+		slot uint32
+	}
+	var keys []storeKey
+	var records bytes.Buffer
+	offsets := make([]uint64, len(slots))
+	enc := binEncoder{index: make(map[string]uint64)}
+	rw := bufio.NewWriter(&records)
+	for slot, i := range slots {
+		e := &dict.Entries[i]
+		rw.Flush()
+		offsets[slot] = uint64(records.Len())
+		enc.reset()
+		enc.entry(e)
+		enc.writeTo(rw)
+
+		var seen []string
+		for _, k := range e.Kanji {
+			seen = append(seen, string(k.Phrase))
+		}
+		for _, r := range e.Reading {
+			if !containsString(seen, string(r.Phrase)) {
+				seen = append(seen, string(r.Phrase))
+			}
+		}
+		for _, k := range seen {
+			keys = append(keys, storeKey{k, uint32(slot)})
+		}
+	}
+	rw.Flush()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].key < keys[j].key
+	})
+
+	blobSize := 0
+	for _, k := range keys {
+		blobSize += len(k.key)
+	}
+	recordsStart := uint64(storeHeaderSize + storeSeqSize*len(slots) +
+		storeKeySize*len(keys) + blobSize)
+
+	crc := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+	bw.WriteString(storeMagic)
+	binary.Write(bw, binary.LittleEndian, []uint32{
+		storeVersion, uint32(len(slots)), uint32(len(keys))})
+	for slot, i := range slots {
+		binary.Write(bw, binary.LittleEndian, []uint64{
+			uint64(dict.Entries[i].Id), recordsStart + offsets[slot]})
+	}
+	off := uint32(0)
+	for _, k := range keys {
+		binary.Write(bw, binary.LittleEndian, []uint32{off, uint32(len(k.key)), k.slot})
+		off += uint32(len(k.key))
+	}
+	for _, k := range keys {
+		bw.WriteString(k.key)
+	}
+	bw.Write(records.Bytes())
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.BigEndian, crc.Sum32())
+}
+
+// A Store is a read-only dictionary held in a byte slice, usually a
+// memory mapped file, from which entries are decoded on demand. Processes
+// which map the same file share a single copy of the dictionary. A Store
+// is safe for concurrent use.
+type Store struct {
+	data   []byte
+	n      int
+	keys   int
+	seqs   []byte
+	index  []byte
+	blob   []byte
+	end    int
+	closer func() error
+	closed bool
+}
+
+// NewStore returns a Store reading from data, which must hold a
+// dictionary written by WriteStore and must not be modified while the
+// Store is in use.
+func NewStore(data []byte) (*Store, error) {
+	if len(data) < storeHeaderSize+4 || string(data[:4]) != storeMagic {
+		return nil, ErrStoreFormat
+	}
+	if v := binary.LittleEndian.Uint32(data[4:]); v != storeVersion {
+		return nil, fmt.Errorf("%w %d", ErrStoreVersion, v)
+	}
+	s := &Store{
+		data: data,
+		n:    int(binary.LittleEndian.Uint32(data[8:])),
+		keys: int(binary.LittleEndian.Uint32(data[12:])),
+		end:  len(data) - 4,
+	}
+	indexStart := storeHeaderSize + storeSeqSize*s.n
+	blobStart := indexStart + storeKeySize*s.keys
+	if blobStart > s.end {
+		return nil, ErrStoreFormat
+	}
+	s.seqs = data[storeHeaderSize:indexStart]
+	s.index = data[indexStart:blobStart]
+	blobEnd := s.end
+	if s.n > 0 {
+		blobEnd = int(binary.LittleEndian.Uint64(s.seqs[8:]))
+	}
+	if blobEnd < blobStart || blobEnd > s.end {
+		return nil, ErrStoreFormat
+	}
+	s.blob = data[blobStart:blobEnd]
+	return s, nil
+}
+
+// Verify checks the store checksum. This reads the whole store.
+func (s *Store) Verify() error {
+	if s.closed {
+		return ErrStoreClosed
+	}
+	if crc32.ChecksumIEEE(s.data[:s.end]) != binary.BigEndian.Uint32(s.data[s.end:]) {
+		return ErrStoreChecksum
+	}
+	return nil
+}
+
+// Close releases the memory backing a store returned by OpenStore. Once
+// closed, the store's methods return ErrStoreClosed and Len returns
+// zero. Close must not be called concurrently with other methods.
+func (s *Store) Close() error {
+	if s.closed {
+		return nil
+	}
+	var err error
+	if s.closer != nil {
+		err = s.closer()
+	}
+	// Drop every slice of the released memory.
+	*s = Store{closed: true}
+	return err
+}
+
+// Len returns the number of entries in the store.
+func (s *Store) Len() int {
+	return s.n
+}
+
+// At decodes the i'th entry in EntSeq order.
+func (s *Store) At(i int) (Entry, error) {
+	var e Entry
+	if s.closed {
+		return e, ErrStoreClosed
+	}
+	if i < 0 || i >= s.n {
+		return e, ErrNotFound
+	}
+	start := binary.LittleEndian.Uint64(s.seqs[i*storeSeqSize+8:])
+	end := uint64(s.end)
+	if i+1 < s.n {
+		end = binary.LittleEndian.Uint64(s.seqs[(i+1)*storeSeqSize+8:])
+	}
+	if start > end || end > uint64(s.end) {
+		return e, ErrStoreFormat
+	}
+	dec := binDecoder{data: s.data[start:end]}
+	dec.table()
+	dec.entry(&e)
+	if dec.err == nil && dec.off != len(dec.data) {
+		dec.err = ErrStoreFormat
+	}
+	return e, dec.err
+}
+
+// Get decodes the entry with the given sequence number.
+func (s *Store) Get(seq EntSeq) (Entry, error) {
+	if s.closed {
+		return Entry{}, ErrStoreClosed
+	}
+	i := sort.Search(s.n, func(i int) bool {
+		return s.seq(i) >= seq
+	})
+	if i == s.n || s.seq(i) != seq {
+		return Entry{}, ErrNotFound
+	}
+	return s.At(i)
+}
+
+// Lookup decodes the entries which have a kanji or reading phrase equal
+// to headword, in EntSeq order.
+func (s *Store) Lookup(headword string) ([]Entry, error) {
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	key := []byte(headword)
+	lo := sort.Search(s.keys, func(i int) bool {
+		return bytes.Compare(s.key(i), key) >= 0
+	})
+	hi := lo
+	for hi < s.keys && bytes.Equal(s.key(hi), key) {
+		hi++
+	}
+	return s.entries(lo, hi, 0)
+}
+
+// Prefix decodes the entries with a kanji or reading phrase beginning
+// with prefix, in phrase order. At most limit entries are returned if
+// limit is positive.
+func (s *Store) Prefix(prefix string, limit int) ([]Entry, error) {
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	key := []byte(prefix)
+	lo := sort.Search(s.keys, func(i int) bool {
+		return bytes.Compare(s.key(i), key) >= 0
+	})
+	hi := lo
+	for hi < s.keys && bytes.HasPrefix(s.key(hi), key) {
+		hi++
+	}
+	return s.entries(lo, hi, limit)
+}
+
+// Decodes the distinct entries referenced by keys lo through hi.
+func (s *Store) entries(lo, hi, limit int) ([]Entry, error) {
+	var entries []Entry
+	seen := make(map[uint32]bool)
+	for i := lo; i < hi && (limit <= 0 || len(entries) < limit); i++ {
+		slot := binary.LittleEndian.Uint32(s.index[i*storeKeySize+8:])
+		if seen[slot] {
+			continue
+		}
+		seen[slot] = true
+		e, err := s.At(int(slot))
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (s *Store) seq(i int) EntSeq {
+	return EntSeq(binary.LittleEndian.Uint64(s.seqs[i*storeSeqSize:]))
+}
+
+func (s *Store) key(i int) []byte {
+	rec := s.index[i*storeKeySize:]
+	off := binary.LittleEndian.Uint32(rec)
+	n := binary.LittleEndian.Uint32(rec[4:])
+	if uint64(off)+uint64(n) > uint64(len(s.blob)) {
+		return nil
+	}
+	return s.blob[off : off+n]
+}
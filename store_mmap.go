@@ -0,0 +1,41 @@
+//go:build unix
+
+package jmdict
+
+import (
+	"os"
+	"syscall"
+)
+
+// OpenStore memory maps the store file at path. The mapping is shared,
+// so every process which opens the same file uses the same pages.
+func OpenStore(path string) (*Store, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.Size() == 0 || int64(int(fi.Size())) != fi.Size() {
+		return nil, ErrStoreFormat
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(fi.Size()),
+		syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewStore(data)
+	if err != nil {
+		syscall.Munmap(data)
+		return nil, err
+	}
+	s.closer = func() error {
+		return syscall.Munmap(data)
+	}
+	return s, nil
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package jmdict
+
+import "os"
+
+// OpenStore reads the store file at path into memory. Platforms without
+// mmap support do not share the store between processes.
+func OpenStore(path string) (*Store, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(data)
+}
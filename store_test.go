@@ -0,0 +1,112 @@
+package jmdict
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Writes the sample dictionary as a store file and opens it.
+func openSampleStore(t *testing.T) (JMDict, *Store) {
+	t.Helper()
+	dict := readSample(t)
+	path := filepath.Join(t.TempDir(), "sample.jmds")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteStore(f, dict); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s, err := OpenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dict, s
+}
+
+func TestStore(t *testing.T) {
+	dict, s := openSampleStore(t)
+	defer s.Close()
+	if err := s.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != len(dict.Entries) {
+		t.Fatalf("Len() = %d, want %d", s.Len(), len(dict.Entries))
+	}
+	for i := range dict.Entries {
+		want := dict.Entries[i]
+		got, err := s.Get(want.Id)
+		if err != nil {
+			t.Fatalf("Get(%d): %v", want.Id, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Get(%d) = %+v, want %+v", want.Id, got, want)
+		}
+	}
+	if _, err := s.Get(1); err != ErrNotFound {
+		t.Errorf("Get(1): got %v, want ErrNotFound", err)
+	}
+
+	entries, err := s.Lookup("こども")
+	if err != nil || len(entries) != 1 || entries[0].Id != 1591900 {
+		t.Errorf("Lookup(こども) = %v, %v", entries, err)
+	}
+	entries, err = s.Prefix("た", 0)
+	if err != nil || len(entries) != 2 {
+		t.Errorf("Prefix(た) gave %d entries, %v; want 2", len(entries), err)
+	}
+}
+
+func TestStoreClose(t *testing.T) {
+	_, s := openSampleStore(t)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d after Close", s.Len())
+	}
+	if _, err := s.Get(1358280); err != ErrStoreClosed {
+		t.Errorf("Get: got %v, want ErrStoreClosed", err)
+	}
+	if _, err := s.At(0); err != ErrStoreClosed {
+		t.Errorf("At: got %v, want ErrStoreClosed", err)
+	}
+	if _, err := s.Lookup("たべる"); err != ErrStoreClosed {
+		t.Errorf("Lookup: got %v, want ErrStoreClosed", err)
+	}
+	if _, err := s.Prefix("た", 0); err != ErrStoreClosed {
+		t.Errorf("Prefix: got %v, want ErrStoreClosed", err)
+	}
+	if err := s.Verify(); err != ErrStoreClosed {
+		t.Errorf("Verify: got %v, want ErrStoreClosed", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
+
+// Stores written before gloss languages were recorded are rejected.
+func TestStoreVersion(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteStore(&buf, readSample(t)); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if _, err := NewStore(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, version := range []uint32{1, storeVersion + 1} {
+		binary.LittleEndian.PutUint32(data[len(storeMagic):], version)
+		if _, err := NewStore(data); !errors.Is(err, ErrStoreVersion) {
+			t.Errorf("version %d store: %v, want ErrStoreVersion", version, err)
+		}
+	}
+}
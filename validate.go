@@ -0,0 +1,174 @@
+package jmdict
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Diagnostic reports a violation of the JMdict DTD constraints found
+// by Validate. Path locates the offending element within the entry
+// using DTD element names and 1-based positions, for instance
+// "r_ele[2].re_restr[1]".
+type Diagnostic struct {
+	Entry   EntSeq `json:"ent_seq"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	if d.Path == "" {
+		return fmt.Sprintf("%d: %s", d.Entry, d.Message)
+	}
+	return fmt.Sprintf("%d %s: %s", d.Entry, d.Path, d.Message)
+}
+
+// Validate checks dict against the constraints described by the DTD:
+//
+//   - every entry has at least one r_ele and one sense
+//   - ent_seq values are unique
+//   - reb contains only kana and related characters
+//   - re_restr, stagk and stagr name a keb or reb of the same entry
+//   - xref and ant name a keb or reb of some entry, and any reading
+//     or sense number they give exists in a matching entry
+//
+// It returns a diagnostic for each violation, in entry order.
+func Validate(dict JMDict) []Diagnostic {
+	var diags []Diagnostic
+	report := func(e *Entry, path, format string, args ...interface{}) {
+		diags = append(diags, Diagnostic{e.Id, path, fmt.Sprintf(format, args...)})
+	}
+
+	byPhrase := make(map[string][]*Entry)
+	seen := make(map[EntSeq]bool, len(dict.Entries))
+	for i := range dict.Entries {
+		e := &dict.Entries[i]
+		for _, k := range e.Kanji {
+			byPhrase[string(k.Phrase)] = append(byPhrase[string(k.Phrase)], e)
+		}
+		for _, r := range e.Reading {
+			byPhrase[string(r.Phrase)] = append(byPhrase[string(r.Phrase)], e)
+		}
+	}
+
+	for i := range dict.Entries {
+		e := &dict.Entries[i]
+		if seen[e.Id] {
+			report(e, "ent_seq", "duplicate ent_seq")
+		}
+		seen[e.Id] = true
+
+		if len(e.Reading) == 0 {
+			report(e, "", "entry has no r_ele")
+		}
+		if len(e.Sense) == 0 {
+			report(e, "", "entry has no sense")
+		}
+
+		kebs := make([]string, len(e.Kanji))
+		for j, k := range e.Kanji {
+			kebs[j] = string(k.Phrase)
+			if k.Phrase == "" {
+				report(e, fmt.Sprintf("k_ele[%d].keb", j+1), "empty keb")
+			}
+		}
+		rebs := make([]string, len(e.Reading))
+		for j, r := range e.Reading {
+			rebs[j] = string(r.Phrase)
+			path := fmt.Sprintf("r_ele[%d]", j+1)
+			if r.Phrase == "" {
+				report(e, path+".reb", "empty reb")
+			}
+			for _, c := range string(r.Phrase) {
+				if !isRebChar(c) {
+					report(e, path+".reb", "reb %q contains non-kana character %q", r.Phrase, c)
+					break
+				}
+			}
+			for l, restr := range r.Restrict {
+				if !containsString(kebs, string(restr)) {
+					report(e, fmt.Sprintf("%s.re_restr[%d]", path, l+1),
+						"re_restr %q does not match a keb", restr)
+				}
+			}
+		}
+
+		for j := range e.Sense {
+			s := &e.Sense[j]
+			path := fmt.Sprintf("sense[%d]", j+1)
+			for l, k := range s.KanjiRestrict {
+				if !containsString(kebs, k) {
+					report(e, fmt.Sprintf("%s.stagk[%d]", path, l+1),
+						"stagk %q does not match a keb", k)
+				}
+			}
+			for l, r := range s.ReadingRestrict {
+				if !containsString(rebs, r) {
+					report(e, fmt.Sprintf("%s.stagr[%d]", path, l+1),
+						"stagr %q does not match a reb", r)
+				}
+			}
+			for l, x := range s.Xref {
+				if msg := checkRef(byPhrase, x); msg != "" {
+					report(e, fmt.Sprintf("%s.xref[%d]", path, l+1), "xref %q %s", x, msg)
+				}
+			}
+			for l, a := range s.Antonym {
+				if msg := checkRef(byPhrase, a); msg != "" {
+					report(e, fmt.Sprintf("%s.ant[%d]", path, l+1), "ant %q %s", a, msg)
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// Checks a cross-reference of the form keb・reb・sense, where the reb and
+// sense number are optional. Returns a description of the problem, or
+// the empty string if some entry satisfies the reference.
+func checkRef(byPhrase map[string][]*Entry, ref string) string {
+	parts := strings.Split(ref, xrefSeparator)
+	targets := byPhrase[parts[0]]
+	if len(targets) == 0 {
+		return "names no keb or reb"
+	}
+	reading, sense := "", 0
+	for _, p := range parts[1:] {
+		if n, err := strconv.Atoi(p); err == nil {
+			sense = n
+		} else {
+			reading = p
+		}
+	}
+	for _, t := range targets {
+		if reading != "" && !t.hasReading(reading) {
+			continue
+		}
+		if sense > len(t.Sense) {
+			continue
+		}
+		return ""
+	}
+	switch {
+	case sense == 0:
+		return fmt.Sprintf("names no entry with reading %q", reading)
+	case reading != "":
+		return fmt.Sprintf("names no entry with reading %q and at least %d senses", reading, sense)
+	}
+	return fmt.Sprintf("names no entry with at least %d senses", sense)
+}
+
+func (e *Entry) hasReading(reb string) bool {
+	for _, r := range e.Reading {
+		if string(r.Phrase) == reb {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether c may appear in a reb: kana, the chouon, the kana
+// iteration marks and the middle dot, plus the ditto mark.
+func isRebChar(c rune) bool {
+	return isKana(c) || c == '〃'
+}
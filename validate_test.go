@@ -0,0 +1,45 @@
+package jmdict
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	dict := readSample(t)
+	// The sample holds only some of JMdict, so 食う is missing.
+	want := []string{`1358280 sense[1].xref[1]: xref "食う・1" names no keb or reb`}
+	if got := diagnosticStrings(Validate(dict)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate(sample) = %q, want %q", got, want)
+	}
+
+	e := sampleEntry(t, dict, 1358280)
+	e.Sense[0].Xref = []string{"行く・いく・1", "高い・2", "高い・3", "行く・こう"}
+	e.Sense[1].Antonym = []string{"べんきょう"}
+	e.Reading[0].Restrict = []ReRestr{"食う"}
+	e.Sense[1].ReadingRestrict = []string{"たべる", "くう"}
+	e = sampleEntry(t, dict, 1280640)
+	e.Reading[0].Phrase = "たか井"
+	dict.Entries = append(dict.Entries, Entry{Id: 1000220})
+	want = []string{
+		`1358280 r_ele[1].re_restr[1]: re_restr "食う" does not match a keb`,
+		`1358280 sense[1].xref[3]: xref "高い・3" names no entry with at least 3 senses`,
+		`1358280 sense[1].xref[4]: xref "行く・こう" names no entry with reading "こう"`,
+		`1358280 sense[2].stagr[2]: stagr "くう" does not match a reb`,
+		`1280640 r_ele[1].reb: reb "たか井" contains non-kana character '井'`,
+		`1000220 ent_seq: duplicate ent_seq`,
+		`1000220: entry has no r_ele`,
+		`1000220: entry has no sense`,
+	}
+	if got := diagnosticStrings(Validate(dict)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate = %q, want %q", got, want)
+	}
+}
+
+func diagnosticStrings(diags []Diagnostic) []string {
+	var strs []string
+	for _, d := range diags {
+		strs = append(strs, d.String())
+	}
+	return strs
+}
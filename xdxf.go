@@ -0,0 +1,173 @@
+package jmdict
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// XDXFInfo holds the metadata written to the meta_info element.
+type XDXFInfo struct {
+	Title       string
+	FullTitle   string
+	Description string
+	LangTo      string // ISO 639-2 code of the gloss language, defaults to ENG
+}
+
+// WriteXDXF writes dict to w as an XDXF (revision 033) dictionary in
+// the logical format. Every kanji and reading element is a key of its
+// article, senses become nested definitions and tags are declared as
+// abbreviations using the description maps. Only glosses in the
+// language of info.LangTo are written. Senses without any are left
+// out, and so are entries with no sense left.
+func WriteXDXF(w io.Writer, dict JMDict, info XDXFInfo) error {
+	if info.LangTo == "" {
+		info.LangTo = "ENG"
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(bw, "<xdxf lang_from=\"JPN\" lang_to=\"%s\" format=\"logical\" revision=\"033\">\n",
+		xmlEscape(info.LangTo))
+	fmt.Fprintf(bw, "<meta_info>\n<title>%s</title>\n<full_title>%s</full_title>\n<description>%s</description>\n",
+		xmlEscape(info.Title), xmlEscape(info.FullTitle), xmlEscape(info.Description))
+
+	grm, stl, knl, aux := map[string]string{}, map[string]string{},
+		map[string]string{}, map[string]string{}
+	for k, v := range positionDescriptions {
+		grm[string(k)] = v
+	}
+	for k, v := range miscDescriptions {
+		stl[string(k)] = v
+	}
+	for k, v := range fieldDescriptions {
+		knl[string(k)] = v
+	}
+	for k, v := range dialectDescriptions {
+		aux[string(k)] = v
+	}
+	for k, v := range orthographyDescriptions {
+		aux[string(k)] = v
+	}
+	bw.WriteString("<abbreviations>\n")
+	writeXDXFAbbrs(bw, "grm", grm)
+	writeXDXFAbbrs(bw, "stl", stl)
+	writeXDXFAbbrs(bw, "knl", knl)
+	writeXDXFAbbrs(bw, "aux", aux)
+	bw.WriteString("</abbreviations>\n</meta_info>\n<lexicon>\n")
+
+	lang := strings.ToLower(info.LangTo)
+	for i := range dict.Entries {
+		writeXDXFArticle(bw, &dict.Entries[i], lang)
+	}
+	bw.WriteString("</lexicon>\n</xdxf>\n")
+	return bw.Flush()
+}
+
+func writeXDXFArticle(w *bufio.Writer, e *Entry, lang string) {
+	glosses := make([][]string, len(e.Sense))
+	empty := true
+	for i := range e.Sense {
+		s := &e.Sense[i]
+		for g, text := range s.Gloss {
+			if s.GlossLanguage(g) == lang {
+				glosses[i] = append(glosses[i], text)
+				empty = false
+			}
+		}
+	}
+	if empty {
+		return
+	}
+
+	w.WriteString("<ar>")
+	var keys []string
+	for _, k := range e.Kanji {
+		keys = append(keys, string(k.Phrase))
+	}
+	for _, r := range e.Reading {
+		if !containsString(keys, string(r.Phrase)) {
+			keys = append(keys, string(r.Phrase))
+		}
+	}
+	for _, k := range keys {
+		fmt.Fprintf(w, "<k>%s</k>", xmlEscape(k))
+	}
+	w.WriteString("\n<def>")
+	// A sense left out may give the part-of-speech of the next.
+	dropped := false
+	for i := range e.Sense {
+		s := &e.Sense[i]
+		if len(glosses[i]) == 0 {
+			dropped = dropped || len(s.Position) > 0
+			continue
+		}
+		positions := s.Position
+		if len(positions) == 0 && dropped {
+			positions = e.Positions(i)
+		}
+		dropped = false
+		w.WriteString("<def>")
+		if len(positions) > 0 {
+			w.WriteString("<gr>")
+			for _, p := range positions {
+				fmt.Fprintf(w, "<abbr>%s</abbr>", xmlEscape(string(p)))
+			}
+			w.WriteString("</gr>")
+		}
+		for _, m := range s.Misc {
+			fmt.Fprintf(w, "<abbr>%s</abbr>", xmlEscape(string(m)))
+		}
+		for _, f := range s.Field {
+			fmt.Fprintf(w, "<abbr>%s</abbr>", xmlEscape(string(f)))
+		}
+		for _, d := range s.Dialect {
+			fmt.Fprintf(w, "<abbr>%s</abbr>", xmlEscape(string(d)))
+		}
+		for _, g := range glosses[i] {
+			fmt.Fprintf(w, "<deftext>%s</deftext>", xmlEscape(g))
+		}
+		for _, info := range s.Info {
+			fmt.Fprintf(w, "<co>%s</co>", xmlEscape(info))
+		}
+		if len(s.Xref) > 0 || len(s.Antonym) > 0 {
+			w.WriteString("<sr>")
+			for _, x := range s.Xref {
+				writeXDXFRef(w, "spv", x)
+			}
+			for _, a := range s.Antonym {
+				writeXDXFRef(w, "ant", a)
+			}
+			w.WriteString("</sr>")
+		}
+		w.WriteString("</def>")
+	}
+	w.WriteString("</def>\n</ar>\n")
+}
+
+// Writes a reference to the headword named by an xref or ant element,
+// dropping any reading or sense number which follows it.
+func writeXDXFRef(w *bufio.Writer, kind, ref string) {
+	target := strings.SplitN(ref, xrefSeparator, 2)[0]
+	fmt.Fprintf(w, "<kref type=\"%s\">%s</kref>", kind, xmlEscape(target))
+}
+
+func writeXDXFAbbrs(w *bufio.Writer, typ string, descs map[string]string) {
+	keys := make([]string, 0, len(descs))
+	for k := range descs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "<abbr_def type=\"%s\"><abbr_k>%s</abbr_k><abbr_v>%s</abbr_v></abbr_def>\n",
+			typ, xmlEscape(k), xmlEscape(descs[k]))
+	}
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package jmdict
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+// Writes dict as XDXF and checks that the output is well formed.
+func writeTestXDXF(t *testing.T, dict JMDict, info XDXFInfo) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := WriteXDXF(&b, dict, info); err != nil {
+		t.Fatal(err)
+	}
+	d := xml.NewDecoder(bytes.NewReader(b.Bytes()))
+	for {
+		if _, err := d.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("output is not XML: %v", err)
+		}
+	}
+	return b.String()
+}
+
+func TestWriteXDXF(t *testing.T) {
+	dict := readSample(t)
+	out := writeTestXDXF(t, dict, XDXFInfo{Title: "JMdict & co"})
+	for _, s := range []string{
+		`lang_to="ENG"`,
+		"<title>JMdict &amp; co</title>",
+		`<abbr_def type="grm"><abbr_k>v1</abbr_k>`,
+		"<ar><k>食べる</k><k>喰べる</k><k>たべる</k>\n",
+		"<deftext>to eat</deftext>",
+		`<kref type="spv">食う</kref>`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("output lacks %q", s)
+		}
+	}
+	for _, s := range []string{"essen", "évident"} {
+		if strings.Contains(out, s) {
+			t.Errorf("English output has %q", s)
+		}
+	}
+	if n := strings.Count(out, "<ar>"); n != 8 {
+		t.Errorf("%d articles, want 8", n)
+	}
+
+	// Only entries with German glosses are written in German.
+	out = writeTestXDXF(t, dict, XDXFInfo{LangTo: "GER"})
+	if n := strings.Count(out, "<ar>"); n != 2 || !strings.Contains(out, "<deftext>essen</deftext>") ||
+		strings.Contains(out, "to eat") {
+		t.Errorf("German output has %d articles:\n%s", n, out[strings.Index(out, "<lexicon>"):])
+	}
+}
+
+func TestWriteXDXFPositions(t *testing.T) {
+	e := Entry{Id: 1, Reading: []REle{{Phrase: "たべる"}}}
+	e.Sense = []Sense{
+		{Position: []Position{Verb1}, Gloss: []string{"essen"}, GlossLang: []string{"ger"}},
+		{Gloss: []string{"to eat"}, GlossLang: []string{"eng"}},
+	}
+	out := writeTestXDXF(t, JMDict{Entries: []Entry{e}}, XDXFInfo{})
+	if !strings.Contains(out, "<def><def><gr><abbr>v1</abbr></gr><deftext>to eat</deftext></def></def>") {
+		t.Errorf("the part-of-speech of a sense left out is not carried over:\n%s", out)
+	}
+}
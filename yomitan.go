@@ -0,0 +1,222 @@
+package jmdict
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// YomitanIndex holds the metadata written to index.json of a Yomitan
+// dictionary package.
+type YomitanIndex struct {
+	Title       string `json:"title"`
+	Revision    string `json:"revision"`
+	Sequenced   bool   `json:"sequenced"`
+	Format      int    `json:"format"`
+	Author      string `json:"author,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Attribution string `json:"attribution,omitempty"`
+}
+
+// Number of rows written to each term_bank_N.json
+const yomitanBankSize = 10000
+
+// Tag applied to pairs which carry a news1, ichi1, spec1 or gai1 priority
+const yomitanPopularTag = "P"
+
+// Yomitan deinflection rule identifiers by part-of-speech. Parts of speech
+// which do not conjugate, or which Yomitan does not know how to
+// deinflect, are absent.
+var yomitanRules = map[Position]string{
+	AdjI:              "adj-i",
+	Verb1:             "v1",
+	Verb5aru:          "v5",
+	Verb5b:            "v5",
+	Verb5g:            "v5",
+	Verb5k:            "v5",
+	Verb5ks:           "v5",
+	Verb5m:            "v5",
+	Verb5n:            "v5",
+	Verb5r:            "v5",
+	Verb5ri:           "v5",
+	Verb5s:            "v5",
+	Verb5t:            "v5",
+	Verb5u:            "v5",
+	Verb5us:           "v5",
+	VerbKuru:          "vk",
+	VerbIrregularSuru: "vs",
+	VerbSuruSpecial:   "vs",
+	VerbZuru:          "vz"}
+
+// WriteYomitan writes dict to w as a Yomitan (format 3) dictionary zip
+// archive. One term is produced for every sense of every valid
+// kanji/reading pair of each entry, so that stagk and stagr
+// restrictions are respected.
+func WriteYomitan(w io.Writer, dict JMDict, index YomitanIndex) error {
+	if index.Format == 0 {
+		index.Format = 3
+	}
+	index.Sequenced = true
+
+	z := zip.NewWriter(w)
+	if err := writeZipJSON(z, "index.json", index); err != nil {
+		return err
+	}
+
+	if err := writeZipJSON(z, "tag_bank_1.json", yomitanTags()); err != nil {
+		return err
+	}
+
+	var bank [][]interface{}
+	n := 0
+	flush := func() error {
+		if len(bank) == 0 {
+			return nil
+		}
+		n++
+		err := writeZipJSON(z, fmt.Sprintf("term_bank_%d.json", n), bank)
+		bank = bank[:0]
+		return err
+	}
+
+	for i := range dict.Entries {
+		for _, term := range yomitanTerms(&dict.Entries[i]) {
+			bank = append(bank, term)
+			if len(bank) == yomitanBankSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return z.Close()
+}
+
+func yomitanTerms(e *Entry) [][]interface{} {
+	var terms [][]interface{}
+	for _, pair := range e.Pairs() {
+		reading := ""
+		if pair.Kanji != nil {
+			reading = string(pair.Reading.Phrase)
+		}
+
+		score := 0
+		common := false
+		for _, pri := range pair.Priorities() {
+			score += yomitanScore(pri)
+			common = common || pri.Common()
+		}
+
+		var termTags []string
+		if common {
+			termTags = append(termTags, yomitanPopularTag)
+		}
+		if pair.Kanji != nil {
+			for _, o := range pair.Kanji.Info {
+				termTags = append(termTags, string(o))
+			}
+		}
+		for _, o := range pair.Reading.Orthography {
+			termTags = append(termTags, string(o))
+		}
+
+		for i := range e.Sense {
+			sense := &e.Sense[i]
+			if !sense.AppliesTo(pair) || len(sense.Gloss) == 0 {
+				continue
+			}
+			positions := e.Positions(i)
+
+			var defTags, rules []string
+			for _, p := range positions {
+				defTags = append(defTags, string(p))
+				if rule, ok := yomitanRules[p]; ok && !containsString(rules, rule) {
+					rules = append(rules, rule)
+				}
+			}
+			for _, m := range sense.Misc {
+				defTags = append(defTags, string(m))
+			}
+			for _, f := range sense.Field {
+				defTags = append(defTags, string(f))
+			}
+			for _, d := range sense.Dialect {
+				defTags = append(defTags, string(d))
+			}
+
+			terms = append(terms, []interface{}{
+				pair.Headword(),
+				reading,
+				strings.Join(defTags, " "),
+				strings.Join(rules, " "),
+				score,
+				sense.Gloss,
+				uint64(e.Id),
+				strings.Join(termTags, " "),
+			})
+		}
+	}
+	return terms
+}
+
+// Contribution of a single priority to the popularity score of a term.
+// Common priorities outweigh everything else, and nfxx ranks contribute
+// more the closer they are to the top of the word frequency list, but
+// never less than any other priority.
+func yomitanScore(pri Priority) int {
+	if pri.Common() {
+		return 10
+	}
+	if pri.Code == Frequency && pri.Rank > 0 && pri.Rank < 50 {
+		if score := (50 - pri.Rank) / 10; score > 1 {
+			return score
+		}
+	}
+	return 1
+}
+
+// yomitanTags builds the tag bank from the description maps, sorted by
+// name. Each row is [name, category, order, notes, score].
+func yomitanTags() [][]interface{} {
+	tags := [][]interface{}{
+		{yomitanPopularTag, "popular", -10, "common word", 10},
+	}
+	for k, v := range positionDescriptions {
+		tags = append(tags, []interface{}{string(k), "partOfSpeech", -3, v, 0})
+	}
+	for k, v := range miscDescriptions {
+		category := ""
+		if k == Archaism || k == Obsolete {
+			category = "archaism"
+		}
+		tags = append(tags, []interface{}{string(k), category, 0, v, 0})
+	}
+	for k, v := range fieldDescriptions {
+		tags = append(tags, []interface{}{string(k), "", 0, v, 0})
+	}
+	for k, v := range dialectDescriptions {
+		tags = append(tags, []interface{}{string(k), "", 0, v, 0})
+	}
+	for k, v := range orthographyDescriptions {
+		tags = append(tags, []interface{}{string(k), "", 0, v, 0})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i][0].(string) < tags[j][0].(string)
+	})
+	return tags
+}
+
+func writeZipJSON(z *zip.Writer, name string, v interface{}) error {
+	f, err := z.Create(name)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(f).Encode(v)
+}
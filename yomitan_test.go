@@ -0,0 +1,99 @@
+package jmdict
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Decodes the named JSON file of a zip archive into v.
+func readZipJSON(t *testing.T, z *zip.Reader, name string, v interface{}) {
+	t.Helper()
+	f, err := z.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(v); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+}
+
+func TestWriteYomitan(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteYomitan(&buf, readSample(t), YomitanIndex{Title: "JMdict", Revision: "2024-01-01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var index YomitanIndex
+	readZipJSON(t, z, "index.json", &index)
+	if index.Title != "JMdict" || index.Revision != "2024-01-01" || index.Format != 3 || !index.Sequenced {
+		t.Errorf("index = %+v", index)
+	}
+
+	var tags [][]interface{}
+	readZipJSON(t, z, "tag_bank_1.json", &tags)
+	categories := make(map[string]interface{})
+	for _, tag := range tags {
+		categories[tag[0].(string)] = tag[1]
+	}
+	if categories["P"] != "popular" || categories["v1"] != "partOfSpeech" || categories["arch"] != "archaism" {
+		t.Errorf("tag categories: P %v, v1 %v, arch %v", categories["P"], categories["v1"], categories["arch"])
+	}
+
+	var terms []json.RawMessage
+	readZipJSON(t, z, "term_bank_1.json", &terms)
+	bySeq := make(map[string][]string)
+	for _, term := range terms {
+		var row []json.RawMessage
+		json.Unmarshal(term, &row)
+		bySeq[string(row[6])] = append(bySeq[string(row[6])], string(term))
+	}
+	want := map[string][]string{
+		"1358280": {
+			`["食べる","たべる","v1 vt","v1",23,["to eat","essen"],1358280,"P"]`,
+			`["食べる","たべる","v1 vt","v1",23,["to live on (e.g. a salary)","to live off"],1358280,"P"]`,
+			`["喰べる","たべる","v1 vt","v1",0,["to eat","essen"],1358280,"iK"]`,
+			`["喰べる","たべる","v1 vt","v1",0,["to live on (e.g. a salary)","to live off"],1358280,"iK"]`,
+		},
+		// The reading marked re_nokanji stands alone.
+		"1080180": {
+			`["倫敦","ロンドン","n","",10,["London"],1080180,"P ateji"]`,
+			`["ロンドン","","n","",0,["London"],1080180,""]`,
+		},
+	}
+	for seq, rows := range want {
+		if got := strings.Join(bySeq[seq], "\n"); got != strings.Join(rows, "\n") {
+			t.Errorf("terms of %s:\n%s\nwant\n%s", seq, got, strings.Join(rows, "\n"))
+		}
+	}
+	// The second sense of 子供 is restricted to こども, so こ has a
+	// term for the first sense only.
+	if rows := bySeq["1591900"]; len(rows) != 7 {
+		t.Errorf("%d terms of 1591900, want 7", len(rows))
+	}
+}
+
+func TestYomitanScore(t *testing.T) {
+	// Scores never fall as the rank improves, and every rank of the
+	// frequency list scores at least as much as any other priority.
+	prev := 0
+	for rank := 49; rank >= 1; rank-- {
+		score := yomitanScore(Priority{Raw: fmt.Sprintf("nf%02d", rank)}.Parse())
+		if score < prev || score < yomitanScore(Priority{Raw: "news2"}.Parse()) {
+			t.Errorf("nf%02d scores %d, after %d", rank, score, prev)
+		}
+		prev = score
+	}
+	if got := yomitanScore(Priority{Raw: "news1"}.Parse()); got != 10 {
+		t.Errorf("news1 scores %d, want 10", got)
+	}
+}